@@ -0,0 +1,33 @@
+package sqlf
+
+// aliased renders an expression followed by its alias.
+type aliased struct {
+	expr  interface{}
+	alias string
+}
+
+// ToSQLFast generates the SQL and returns it, alongside its params.
+func (a *aliased) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
+	err := RenderInterfaceAsSQL(sb, args, a.expr)
+	if err != nil {
+		return err
+	}
+	sb.Write(sqlSelectAsClause)
+	identifierQuoterOf(sb).QuoteIdentifier(sb, a.alias)
+	return nil
+}
+
+// As renders `<expr> AS "<alias>"`. The `expr` can be a string or any `FastSqlizer`, and its args are kept. The
+// alias is always quoted, using the quoting style of the statement (check `QuoteIdentifiers`), so it is safe to use
+// any name.
+//
+// Example:
+//
+//	s.Select("id", sqlf.As(sqlf.Func("COALESCE", "nickname", sqlf.Arg("anonymous")), "name"))
+//	// SELECT id, COALESCE(nickname, ?) AS "name"
+func As(expr interface{}, alias string) FastSqlizer {
+	return &aliased{
+		expr:  expr,
+		alias: alias,
+	}
+}
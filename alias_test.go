@@ -0,0 +1,62 @@
+package sqlf_test
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/jamillosantos/sqlf"
+	"github.com/jamillosantos/sqlf/testingutils"
+)
+
+var _ = Describe("As", func() {
+	It("should alias a string field", func() {
+		sb, args := new(strings.Builder), make([]interface{}, 0)
+		err := sqlf.As("u.name", "name").ToSQLFast(sb, &args)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(BeEmpty())
+		Expect(sb.String()).To(Equal(`u.name AS "name"`))
+	})
+
+	It("should escape quotes in the alias", func() {
+		sb, args := new(strings.Builder), make([]interface{}, 0)
+		err := sqlf.As("name", `the "name"`).ToSQLFast(sb, &args)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sb.String()).To(Equal(`name AS "the ""name"""`))
+	})
+
+	It("should fail aliasing an errored expression", func() {
+		sb, args := new(strings.Builder), make([]interface{}, 0)
+		err := sqlf.As(&testingutils.MockerSqlizer{
+			Err: errors.New("forced error"),
+		}, "name").ToSQLFast(sb, &args)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("forced error"))
+	})
+
+	It("should alias parameterized select fields", func() {
+		sql, args, err := new(sqlf.SelectStatement).
+			Placeholder(sqlf.DollarPlaceholder).
+			Select("id", sqlf.As(sqlf.Func("COALESCE", "nickname", sqlf.Arg("anonymous")), "name")).
+			From("users").
+			Where("id = ?", 1).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(Equal([]interface{}{"anonymous", 1}))
+		Expect(sql).To(Equal(`SELECT id, COALESCE(nickname, $1) AS "name" FROM users WHERE id = $2`))
+	})
+
+	It("should alias returning fields", func() {
+		insert := new(sqlf.InsertStatement)
+		sql, args, err := insert.
+			Into("users", "name").
+			Values("Name 1").
+			Returning("id", sqlf.As(sqlf.Condition("created_at AT TIME ZONE ?", "UTC"), "created_at_utc")).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(Equal([]interface{}{"Name 1", "UTC"}))
+		Expect(sql).To(Equal(`INSERT INTO users (name) VALUES (?) RETURNING id, created_at AT TIME ZONE ? AS "created_at_utc"`))
+	})
+})
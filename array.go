@@ -0,0 +1,226 @@
+package sqlf
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	sqlArrayEqAny        = []byte(" = ANY(")
+	sqlArrayNotEqAll     = []byte(" <> ALL(")
+	sqlArrayContains     = []byte(" @> ")
+	sqlArrayContainedBy  = []byte(" <@ ")
+	sqlArrayOverlap      = []byte(" && ")
+	sqlArrayNoBracket    = []byte("")
+	pgArrayNull          = "NULL"
+	pgArrayQuoteReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+)
+
+// PostgresArray is a `driver.Valuer` that sends a Go slice to Postgres as a single array argument.
+//
+// The slice is encoded using the Postgres array literal format (`{1,2,3}`), so it works with any driver that
+// accepts strings as arguments.
+type PostgresArray struct {
+	value interface{}
+}
+
+// Array wraps a Go slice (or array) into a `PostgresArray` that can be used as an argument.
+func Array(value interface{}) *PostgresArray {
+	return &PostgresArray{
+		value: value,
+	}
+}
+
+// Value implements the `driver.Valuer` interface.
+func (a *PostgresArray) Value() (driver.Value, error) {
+	if isNil(a.value) {
+		return nil, nil
+	}
+	v := reflect.ValueOf(a.value)
+	if v.Kind() == reflect.Slice && v.IsNil() {
+		return nil, nil
+	}
+	var sb strings.Builder
+	err := writePostgresArray(&sb, v)
+	if err != nil {
+		return nil, err
+	}
+	return sb.String(), nil
+}
+
+// writePostgresArray writes the array literal of `v` into `sb`.
+func writePostgresArray(sb *strings.Builder, v reflect.Value) error {
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return fmt.Errorf("sqlf: cannot use %s as a postgres array", v.Type())
+	}
+	sb.WriteByte('{')
+	for i := 0; i < v.Len(); i++ {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		err := writePostgresArrayElement(sb, v.Index(i))
+		if err != nil {
+			return err
+		}
+	}
+	sb.WriteByte('}')
+	return nil
+}
+
+// writePostgresArrayElement writes a single element of an array literal into `sb`.
+func writePostgresArrayElement(sb *strings.Builder, v reflect.Value) error {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			sb.WriteString(pgArrayNull)
+			return nil
+		}
+		if valuer, ok := v.Interface().(driver.Valuer); ok {
+			return writePostgresArrayValuer(sb, valuer)
+		}
+		v = v.Elem()
+	}
+
+	element := v.Interface()
+	switch e := element.(type) {
+	case driver.Valuer:
+		return writePostgresArrayValuer(sb, e)
+	case []byte:
+		sb.WriteString(`"\\x`)
+		sb.WriteString(hex.EncodeToString(e))
+		sb.WriteByte('"')
+		return nil
+	case string:
+		writePostgresArrayString(sb, e)
+		return nil
+	case time.Time:
+		writePostgresArrayString(sb, e.Format(time.RFC3339Nano))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		return writePostgresArray(sb, v)
+	case reflect.Bool:
+		if v.Bool() {
+			sb.WriteByte('t')
+		} else {
+			sb.WriteByte('f')
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		sb.WriteString(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		sb.WriteString(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32:
+		sb.WriteString(strconv.FormatFloat(v.Float(), 'g', -1, 32))
+	case reflect.Float64:
+		sb.WriteString(strconv.FormatFloat(v.Float(), 'g', -1, 64))
+	case reflect.String:
+		writePostgresArrayString(sb, v.String())
+	default:
+		writePostgresArrayString(sb, fmt.Sprint(element))
+	}
+	return nil
+}
+
+// writePostgresArrayValuer writes the value returned by a `driver.Valuer` as an array element.
+func writePostgresArrayValuer(sb *strings.Builder, valuer driver.Valuer) error {
+	value, err := valuer.Value()
+	if err != nil {
+		return err
+	}
+	if value == nil {
+		sb.WriteString(pgArrayNull)
+		return nil
+	}
+	return writePostgresArrayElement(sb, reflect.ValueOf(value))
+}
+
+// writePostgresArrayString writes a quoted and escaped string as an array element.
+func writePostgresArrayString(sb *strings.Builder, s string) {
+	sb.WriteByte('"')
+	pgArrayQuoteReplacer.WriteString(sb, s)
+	sb.WriteByte('"')
+}
+
+// arrayPredicate renders the Postgres array predicates. The value is bound as a single array argument.
+type arrayPredicate struct {
+	column   interface{}
+	operator []byte
+	closing  []byte
+	values   interface{}
+}
+
+// ToSQLFast generates the SQL and returns it, alongside its params.
+func (p *arrayPredicate) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
+	err := checkFeature(sb, FeatureArrays)
+	if err != nil {
+		return err
+	}
+	err = RenderInterfaceAsSQL(sb, args, p.column)
+	if err != nil {
+		return err
+	}
+	sb.Write(p.operator)
+	switch v := p.values.(type) {
+	case FastSqlizer:
+		// The operators without brackets of their own (Ex: `@>`) need them around a subquery.
+		bracket := len(p.closing) == 0
+		if bracket {
+			sb.Write(sqlBracketOpen)
+		}
+		err = v.ToSQLFast(sb, args)
+		if err != nil {
+			return err
+		}
+		if bracket {
+			sb.Write(sqlBracketClose)
+		}
+	case driver.Valuer:
+		sb.Write(sqlPredicatePlaceholder)
+		*args = append(*args, v)
+	default:
+		sb.Write(sqlPredicatePlaceholder)
+		*args = append(*args, Array(v))
+	}
+	sb.Write(p.closing)
+	return nil
+}
+
+// EqAny renders `<column> = ANY(?)` binding `values` as a single Postgres array argument.
+//
+// `values` can be a Go slice (wrapped with `Array`), any `driver.Valuer` or a `FastSqlizer` (like a `Select`).
+func EqAny(column interface{}, values interface{}) FastSqlizer {
+	return &arrayPredicate{column: column, operator: sqlArrayEqAny, closing: sqlBracketClose, values: values}
+}
+
+// NotEqAll renders `<column> <> ALL(?)` binding `values` as a single Postgres array argument.
+//
+// `values` can be a Go slice (wrapped with `Array`), any `driver.Valuer` or a `FastSqlizer` (like a `Select`).
+func NotEqAll(column interface{}, values interface{}) FastSqlizer {
+	return &arrayPredicate{column: column, operator: sqlArrayNotEqAll, closing: sqlBracketClose, values: values}
+}
+
+// ArrayContains renders `<column> @> ?`, true when the `column` array contains all `values`.
+//
+// `values` can be a Go slice (wrapped with `Array`), any `driver.Valuer` or a `FastSqlizer` (like a `Select`),
+// rendered in brackets.
+func ArrayContains(column interface{}, values interface{}) FastSqlizer {
+	return &arrayPredicate{column: column, operator: sqlArrayContains, closing: sqlArrayNoBracket, values: values}
+}
+
+// ArrayContainedBy renders `<column> <@ ?`, true when all elements of the `column` array are in `values`.
+// `values` are rendered as in `ArrayContains`.
+func ArrayContainedBy(column interface{}, values interface{}) FastSqlizer {
+	return &arrayPredicate{column: column, operator: sqlArrayContainedBy, closing: sqlArrayNoBracket, values: values}
+}
+
+// ArrayOverlap renders `<column> && ?`, true when the `column` array and `values` have elements in common.
+// `values` are rendered as in `ArrayContains`.
+func ArrayOverlap(column interface{}, values interface{}) FastSqlizer {
+	return &arrayPredicate{column: column, operator: sqlArrayOverlap, closing: sqlArrayNoBracket, values: values}
+}
@@ -0,0 +1,117 @@
+package sqlf_test
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/jamillosantos/sqlf"
+	"github.com/jamillosantos/sqlf/testingutils"
+)
+
+type arrayTestValuer struct {
+	value driver.Value
+}
+
+func (v arrayTestValuer) Value() (driver.Value, error) {
+	return v.value, nil
+}
+
+var _ = Describe("Array", func() {
+	DescribeTable("Value",
+		func(input interface{}, expected driver.Value) {
+			value, err := sqlf.Array(input).Value()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(expected))
+		},
+		Entry("ints", []int{1, 2, 3}, "{1,2,3}"),
+		Entry("empty", []int{}, "{}"),
+		Entry("strings", []string{"a", `b"c`, `d\e`, "NULL"}, `{"a","b\"c","d\\e","NULL"}`),
+		Entry("floats", []float64{1.5, 2}, "{1.5,2}"),
+		Entry("bools", []bool{true, false}, "{t,f}"),
+		Entry("bytes", [][]byte{[]byte("ab")}, `{"\\x6162"}`),
+		Entry("nullable", []*int{nil}, "{NULL}"),
+		Entry("times", []time.Time{time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)}, `{"2021-01-02T03:04:05Z"}`),
+		Entry("valuers", []driver.Valuer{arrayTestValuer{int64(1)}, arrayTestValuer{nil}}, "{1,NULL}"),
+		Entry("multidimensional", [][]int{{1, 2}, {3, 4}}, "{{1,2},{3,4}}"),
+	)
+
+	It("should encode nil slices as NULL", func() {
+		value, err := sqlf.Array([]int(nil)).Value()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(BeNil())
+	})
+
+	It("should fail encoding a non slice", func() {
+		_, err := sqlf.Array(1).Value()
+		Expect(err).To(HaveOccurred())
+	})
+
+	DescribeTable("predicates",
+		func(sqlizer sqlf.FastSqlizer, expectedSQL string, expectedArg driver.Value) {
+			sb, args := new(strings.Builder), make([]interface{}, 0)
+			err := sqlizer.ToSQLFast(sb, &args)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sb.String()).To(Equal(expectedSQL))
+			Expect(args).To(HaveLen(1))
+			value, err := args[0].(driver.Valuer).Value()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(expectedArg))
+		},
+		Entry("EqAny", sqlf.EqAny("id", []int64{1, 2}), "id = ANY(?)", "{1,2}"),
+		Entry("NotEqAll", sqlf.NotEqAll("status", []string{"a", "b"}), "status <> ALL(?)", `{"a","b"}`),
+		Entry("ArrayContains", sqlf.ArrayContains("tags", []string{"go"}), "tags @> ?", `{"go"}`),
+		Entry("ArrayContainedBy", sqlf.ArrayContainedBy("tags", []string{"go"}), "tags <@ ?", `{"go"}`),
+		Entry("ArrayOverlap", sqlf.ArrayOverlap("tags", []string{"go"}), "tags && ?", `{"go"}`),
+		Entry("with a driver.Valuer", sqlf.EqAny("id", arrayTestValuer{"{1}"}), "id = ANY(?)", "{1}"),
+	)
+
+	It("should generate ANY with a subquery", func() {
+		sb, args := new(strings.Builder), make([]interface{}, 0)
+		err := sqlf.EqAny("id", new(sqlf.SelectStatement).Select("user_id").From("admins")).ToSQLFast(sb, &args)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(BeEmpty())
+		Expect(sb.String()).To(Equal("id = ANY(SELECT user_id FROM admins)"))
+	})
+
+	It("should generate the array operators with a subquery", func() {
+		sql, args, err := new(sqlf.SelectStatement).
+			Placeholder(sqlf.DollarPlaceholder).
+			From("posts").
+			WhereCriteria(
+				sqlf.ArrayContains("tags", new(sqlf.SelectStatement).Select("tags").From("topics").Where("id = ?", 1)),
+				sqlf.ArrayContainedBy("tags", new(sqlf.SelectStatement).Select("allowed").From("settings")),
+				sqlf.ArrayOverlap("tags", sqlf.Condition("ARRAY[?]", "go")),
+			).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("SELECT * FROM posts WHERE tags @> (SELECT tags FROM topics WHERE id = $1) AND tags <@ (SELECT allowed FROM settings) AND tags && (ARRAY[$2])"))
+		Expect(args).To(Equal([]interface{}{1, "go"}))
+	})
+
+	It("should fail generating an errored column", func() {
+		sb, args := new(strings.Builder), make([]interface{}, 0)
+		err := sqlf.EqAny(&testingutils.MockerSqlizer{
+			Err: errors.New("forced error"),
+		}, []int{1}).ToSQLFast(sb, &args)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("forced error"))
+	})
+
+	It("should be used as a criteria with the dollar placeholder", func() {
+		sql, args, err := new(sqlf.SelectStatement).
+			Placeholder(sqlf.DollarPlaceholder).
+			From("users").
+			Where("account_id = ?", 7).
+			WhereCriteria(sqlf.EqAny("id", []int{1, 2, 3}), sqlf.ArrayOverlap("roles", []string{"admin"})).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(HaveLen(3))
+		Expect(sql).To(Equal("SELECT * FROM users WHERE account_id = $1 AND id = ANY($2) AND roles && $3"))
+	})
+})
@@ -57,6 +57,20 @@ func BenchmarkSQLFSelectSQLGeneration(b *testing.B) {
 	}
 }
 
+func BenchmarkSQLFSelectSQLGenerationDollar(b *testing.B) {
+	s := new(sqlf.SelectStatement)
+	s.Placeholder(sqlf.DollarPlaceholder).From("users", "u").InnerJoin("permissions", "p").On("p.user_id = u.id").Where("u.age >= ?", 18)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _, err := s.ToSQL()
+		if err != nil {
+			fmt.Println(err)
+			b.Fail()
+			return
+		}
+	}
+}
+
 func BenchmarkSquirrelSelectSQLGeneration(b *testing.B) {
 	s := sq.Select("*").From("users u").InnerJoin("permissions ON p.user_id = u.id").Where("u.age >= ?", 18)
 	for i := 0; i < b.N; i++ {
@@ -88,6 +102,10 @@ func BenchmarkSQLFDollarPlaceholder(b *testing.B) {
 	ph := sqlf.DollarPlaceholder.Wrap(sb)
 	for i := 0; i < b.N; i++ {
 		_, err := ph.WriteString(sqlForPlaceholder)
+		if err == nil {
+			// The trailing `?` is held until the writer is flushed, as statements do when they finish rendering.
+			err = ph.(sqlf.PlaceholderFlusher).Flush()
+		}
 		if err != nil {
 			fmt.Println(err)
 			b.Fail()
@@ -0,0 +1,183 @@
+package sqlf
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// ErrInvalidBindings is returned when the value given to `Bind` is not a map with string keys nor a struct.
+	ErrInvalidBindings = errors.New("bindings must be a map with string keys or a struct")
+
+	// ErrMissingBinding is the error matched, through `errors.Is`, by any `MissingBindingError`.
+	ErrMissingBinding = errors.New("missing binding")
+)
+
+// MissingBindingError is returned when a condition uses a named parameter (`:name`) that was not bound.
+type MissingBindingError struct {
+	Name string
+}
+
+// Error implements the `error` interface.
+func (err *MissingBindingError) Error() string {
+	return fmt.Sprintf("sqlf: the named parameter :%s was not bound", err.Name)
+}
+
+// Is makes `errors.Is(err, ErrMissingBinding)` match.
+func (err *MissingBindingError) Is(target error) bool {
+	return target == ErrMissingBinding
+}
+
+// NamedPlaceholderWriter is implemented by the placeholder writers (created by `PlaceholderFormatFactory.Wrap`)
+// that can reuse the placeholder of a named parameter that appears more than once. Ex: `DollarPlaceholder`
+// renders `:id` as `$1` every time.
+type NamedPlaceholderWriter interface {
+	// WriteNamed writes the placeholder of the named parameter `name`. It returns true when the placeholder of a
+	// previous occurrence was reused, so its value must not be bound again.
+	WriteNamed(name string) (reused bool)
+}
+
+// bindings holds the values of the named parameters of a statement.
+type bindings struct {
+	values map[string]interface{}
+	// err is the error found when binding the values. It is returned when the statement is rendered.
+	err error
+}
+
+// newBindings creates the bindings from a map with string keys or a struct (or a pointer to them). A nil `value`
+// removes the bindings.
+//
+// Struct fields are named by their `db` tag, falling back to the field name. Fields tagged with `db:"-"` are
+// ignored, and embedded structs are flattened (check `structMetadataOf`).
+func newBindings(value interface{}) *bindings {
+	if value == nil {
+		return nil
+	}
+	if values, ok := value.(map[string]interface{}); ok {
+		return &bindings{values: values}
+	}
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	switch {
+	case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+		values := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			values[iter.Key().String()] = iter.Value().Interface()
+		}
+		return &bindings{values: values}
+	case v.Kind() == reflect.Struct:
+		values := make(map[string]interface{})
+		bindStruct(values, v)
+		return &bindings{values: values}
+	}
+	return &bindings{
+		err: fmt.Errorf("%w: got %T", ErrInvalidBindings, value),
+	}
+}
+
+// bindError returns the error found when binding the values, if any.
+func (b *bindings) bindError() error {
+	if b == nil {
+		return nil
+	}
+	return b.err
+}
+
+// bindStruct adds the fields of the struct `v` to `values`. Check `structMetadataOf`.
+func bindStruct(values map[string]interface{}, v reflect.Value) {
+	for _, field := range structMetadataOf(v.Type()).fields {
+		fv, ok := fieldByIndex(v, field.index, false)
+		if !ok {
+			continue
+		}
+		values[field.name] = fv.Interface()
+	}
+}
+
+// bindingsOf returns the bindings of the statement being rendered, if any.
+func bindingsOf(sb SQLWriter) *bindings {
+	if ctx, ok := contextOf(sb); ok {
+		return ctx.bindings
+	}
+	return nil
+}
+
+// writeNamedPlaceholder writes the placeholder of the named parameter `name`, reusing the placeholder of a
+// previous occurrence when the placeholder format supports it (check `NamedPlaceholderWriter`).
+func writeNamedPlaceholder(sb SQLWriter, name string) (reused bool) {
+	if w, ok := unwrapRenderWriter(sb).(NamedPlaceholderWriter); ok {
+		return w.WriteNamed(name)
+	}
+	sb.Write(sqlPredicatePlaceholder)
+	return false
+}
+
+// parseParameterName returns the name of the named parameter at the beginning of `s` (right after the `:`). It
+// returns an empty string if `s` does not start with a name.
+func parseParameterName(s string) string {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '_':
+		case i > 0 && c >= '0' && c <= '9':
+		default:
+			return s[:i]
+		}
+	}
+	return s
+}
+
+// namedParameter is a `:name` parameter found in a sql.
+type namedParameter struct {
+	// start is the position of the `:`.
+	start int
+	name  string
+}
+
+// parseNamedParameters returns the `:name` parameters of `sql`, ignoring `::` casts, array slices (Ex:
+// `tags[lo:hi]`), string literals, quoted identifiers and comments. `syntax` are the lexer rules of the dialect.
+func parseNamedParameters(sql string, syntax lexerSyntax) []namedParameter {
+	var parameters []namedParameter
+	lexer := sqlLexer{syntax: syntax}
+	// brackets is the depth of the array subscripts, where a `:` separates the bounds of a slice.
+	brackets := 0
+	for i := 0; i < len(sql); i++ {
+		if !lexer.next(sql[i]) {
+			continue
+		}
+		if sql[i] == '[' {
+			brackets++
+			continue
+		}
+		if sql[i] == ']' && brackets > 0 {
+			brackets--
+			continue
+		}
+		if sql[i] != ':' || brackets > 0 {
+			continue
+		}
+		if i+1 < len(sql) && sql[i+1] == ':' {
+			// Cast (Ex: `id::text`), not a named parameter.
+			lexer.next(sql[i+1])
+			i++
+			continue
+		}
+		name := parseParameterName(sql[i+1:])
+		if name == "" {
+			continue
+		}
+		parameters = append(parameters, namedParameter{
+			start: i,
+			name:  name,
+		})
+		for j := i + 1; j <= i+len(name); j++ {
+			lexer.next(sql[j])
+		}
+		i += len(name)
+	}
+	return parameters
+}
@@ -0,0 +1,219 @@
+package sqlf_test
+
+import (
+	dbsql "database/sql"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/jamillosantos/sqlf"
+)
+
+var _ = Describe("Bind", func() {
+	since := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	It("should bind named parameters from a map", func() {
+		sql, args, err := new(sqlf.SelectStatement).
+			From("users").
+			Where("created_at > :since AND role = ?", "admin").
+			Bind(map[string]interface{}{"since": since}).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("SELECT * FROM users WHERE created_at > ? AND role = ?"))
+		Expect(args).To(Equal([]interface{}{since, "admin"}))
+	})
+
+	It("should bind named parameters from a struct", func() {
+		type embedded struct {
+			Role string `db:"role"`
+		}
+		type filter struct {
+			embedded
+			Since   time.Time `db:"since"`
+			Limit   int
+			Ignored string `db:"-"`
+		}
+		sql, args, err := new(sqlf.SelectStatement).
+			From("users").
+			Where("created_at > :since AND role = :role AND level < :Limit").
+			Bind(&filter{embedded: embedded{Role: "admin"}, Since: since, Limit: 3}).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("SELECT * FROM users WHERE created_at > ? AND role = ? AND level < ?"))
+		Expect(args).To(Equal([]interface{}{since, "admin", 3}))
+	})
+
+	It("should bind the same statement per request", func() {
+		q := new(sqlf.SelectStatement).From("users").Where("id = :id")
+		_, args, err := q.Bind(map[string]interface{}{"id": 1}).ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(Equal([]interface{}{1}))
+		_, args, err = q.Bind(map[string]int{"id": 2}).ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(Equal([]interface{}{2}))
+	})
+
+	It("should reuse the placeholder of a repeated name", func() {
+		sql, args, err := new(sqlf.SelectStatement).
+			Placeholder(sqlf.DollarPlaceholder).
+			From("events").
+			Where("kind = ?", "login").
+			Where("(starts_at > :since OR ends_at > :since) AND id <> :id").
+			Bind(map[string]interface{}{"since": since, "id": 7}).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("SELECT * FROM events WHERE kind = $1 AND (starts_at > $2 OR ends_at > $2) AND id <> $3"))
+		Expect(args).To(Equal([]interface{}{"login", since, 7}))
+	})
+
+	It("should repeat the value of a repeated name with the question format", func() {
+		sql, args, err := new(sqlf.SelectStatement).
+			From("events").
+			Where("starts_at > :since OR ends_at > :since").
+			Bind(map[string]interface{}{"since": since}).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("SELECT * FROM events WHERE starts_at > ? OR ends_at > ?"))
+		Expect(args).To(Equal([]interface{}{since, since}))
+	})
+
+	It("should bind named args with a named format", func() {
+		sql, args, err := new(sqlf.SelectStatement).
+			Placeholder(sqlf.NamedAtPlaceholder).
+			From("events").
+			Where("starts_at > :since OR ends_at > :since").
+			Bind(map[string]interface{}{"since": since}).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("SELECT * FROM events WHERE starts_at > @since OR ends_at > @since"))
+		Expect(args).To(Equal([]interface{}{dbsql.Named("since", since)}))
+	})
+
+	It("should name only the positional placeholders with a named format", func() {
+		sql, args, err := new(sqlf.SelectStatement).
+			Placeholder(sqlf.NamedColonPlaceholder).
+			From("events").
+			Where("kind = ? AND (starts_at > :since OR ends_at > :since) AND id IN (:ids) AND level < ?", "login", 3).
+			Bind(map[string]interface{}{"since": since, "ids": []int{1, 2}}).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("SELECT * FROM events WHERE kind = :p1 AND (starts_at > :since OR ends_at > :since) AND id IN (:p3, :p4) AND level < :p5"))
+		Expect(args).To(Equal([]interface{}{
+			dbsql.Named("p1", "login"),
+			dbsql.Named("since", since),
+			dbsql.Named("p3", 1),
+			dbsql.Named("p4", 2),
+			dbsql.Named("p5", 3),
+		}))
+	})
+
+	It("should expand a slice binding", func() {
+		sql, args, err := new(sqlf.SelectStatement).
+			Placeholder(sqlf.DollarPlaceholder).
+			From("users").
+			Where("id IN (:ids)").
+			Bind(map[string]interface{}{"ids": []int{1, 2}}).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("SELECT * FROM users WHERE id IN ($1, $2)"))
+		Expect(args).To(Equal([]interface{}{1, 2}))
+	})
+
+	It("should replace the predicate of an empty slice binding", func() {
+		sql, args, err := new(sqlf.SelectStatement).
+			From("users").
+			Where("id IN (:ids) OR role NOT IN (:roles) OR name = :name").
+			Bind(map[string]interface{}{"ids": []int{}, "roles": []string{}, "name": "john"}).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("SELECT * FROM users WHERE (1=0) OR (1=1) OR name = ?"))
+		Expect(args).To(Equal([]interface{}{"john"}))
+	})
+
+	It("should ignore casts and string literals", func() {
+		sql, args, err := new(sqlf.SelectStatement).
+			From("users").
+			Where("id::text = :id AND created_at > '2021-01-01 10:30' AND note <> ':id'").
+			Bind(map[string]interface{}{"id": "1"}).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("SELECT * FROM users WHERE id::text = ? AND created_at > '2021-01-01 10:30' AND note <> ':id'"))
+		Expect(args).To(Equal([]interface{}{"1"}))
+	})
+
+	It("should render the names as they are when nothing is bound", func() {
+		sql, args, err := new(sqlf.SelectStatement).From("users").Where("id = :id AND role = ?", "admin").ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("SELECT * FROM users WHERE id = :id AND role = ?"))
+		Expect(args).To(Equal([]interface{}{"admin"}))
+	})
+
+	It("should not take array slices for named parameters", func() {
+		sql, args, err := new(sqlf.SelectStatement).From("posts").Where("tags[lo:hi] = ? AND tags[:2] <> tags[1:hi]", 1).ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("SELECT * FROM posts WHERE tags[lo:hi] = ? AND tags[:2] <> tags[1:hi]"))
+		Expect(args).To(Equal([]interface{}{1}))
+
+		sql, args, err = new(sqlf.SelectStatement).
+			From("posts").
+			Where("tags[lo:hi] = :tags AND matrix[1:2][:hi] IS NOT NULL").
+			Bind(map[string]interface{}{"tags": "{a}"}).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("SELECT * FROM posts WHERE tags[lo:hi] = ? AND matrix[1:2][:hi] IS NOT NULL"))
+		Expect(args).To(Equal([]interface{}{"{a}"}))
+	})
+
+	It("should inherit the bindings in nested statements", func() {
+		sql, args, err := new(sqlf.SelectStatement).
+			From("users").
+			WhereCriteria(sqlf.In("id", new(sqlf.SelectStatement).Select("user_id").From("orders").Where("total > :total"))).
+			Bind(map[string]interface{}{"total": 100}).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("SELECT * FROM users WHERE id IN (SELECT user_id FROM orders WHERE total > ?)"))
+		Expect(args).To(Equal([]interface{}{100}))
+	})
+
+	It("should bind updates and deletes", func() {
+		sql, args, err := new(sqlf.UpdateStatement).
+			Table("users").
+			Set("active", false).
+			Where("last_login < :since").
+			Bind(map[string]interface{}{"since": since}).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("UPDATE users SET active = ? WHERE last_login < ?"))
+		Expect(args).To(Equal([]interface{}{false, since}))
+
+		sql, args, err = new(sqlf.DeleteStatement).
+			From("users").
+			Where("last_login < :since").
+			Bind(map[string]interface{}{"since": since}).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("DELETE FROM users WHERE last_login < ?"))
+		Expect(args).To(Equal([]interface{}{since}))
+	})
+
+	It("should fail when a name is not bound", func() {
+		_, _, err := new(sqlf.SelectStatement).
+			From("users").
+			Where("created_at > :since").
+			Bind(map[string]interface{}{}).
+			ToSQL()
+		Expect(err).To(MatchError(sqlf.ErrMissingBinding))
+		Expect(err).To(MatchError(`sqlf: SELECT WHERE #1 "created_at > :since": the named parameter :since was not bound`))
+		var missingErr *sqlf.MissingBindingError
+		Expect(errors.As(err, &missingErr)).To(BeTrue())
+		Expect(missingErr.Name).To(Equal("since"))
+	})
+
+	It("should fail binding an invalid value", func() {
+		_, _, err := new(sqlf.SelectStatement).From("users").Bind(1).ToSQL()
+		Expect(errors.Is(err, sqlf.ErrInvalidBindings)).To(BeTrue())
+		Expect(err).To(MatchError("sqlf: SELECT BIND: bindings must be a map with string keys or a struct: got int"))
+	})
+})
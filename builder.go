@@ -3,6 +3,12 @@ package sqlf
 // Builder is responsible to build SelectStatements with a default configuration.
 type Builder interface {
 	Placeholder(format PlaceholderFormatFactory) Builder
+	QuoteIdentifiers(quoter IdentifierQuoter) Builder
+	Dialect(dialect Dialect) Builder
+	Strict() Builder
+	EmptySlices(style EmptySliceStyle) Builder
+	Registry(registry *Registry) Builder
+	RunWith(db Executor) Builder
 	Select(fields ...string) Select
 	Insert(tableName string, fields ...interface{}) Insert
 	Delete(tableName ...string) Delete
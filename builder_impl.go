@@ -2,6 +2,12 @@ package sqlf
 
 type builder struct {
 	placeholder PlaceholderFormatFactory
+	quoter      IdentifierQuoter
+	dialect     Dialect
+	strict      bool
+	emptySlices EmptySliceStyle
+	registry    *Registry
+	executor    Executor
 }
 
 // NewBuilder returns a new instance of the default implementation of the `Builder`.
@@ -14,15 +20,68 @@ func (b *builder) Placeholder(format PlaceholderFormatFactory) Builder {
 	return b
 }
 
+// QuoteIdentifiers enables quoting every table and alias, of the statements created by the builder, using `quoter`.
+func (b *builder) QuoteIdentifiers(quoter IdentifierQuoter) Builder {
+	b.quoter = quoter
+	return b
+}
+
+// Dialect defines the database technology of the statements created by the builder. Ex:
+//
+//	b := sqlf.NewBuilder().Dialect(sqlf.Postgres)
+func (b *builder) Dialect(dialect Dialect) Builder {
+	b.dialect = dialect
+	return b
+}
+
+// Strict enables the strict mode for the statements created by the builder. Check `Select.Strict`.
+func (b *builder) Strict() Builder {
+	b.strict = true
+	return b
+}
+
+// EmptySlices defines how the empty slice arguments of the conditions, of the statements created by the builder, are
+// rendered. Check `Select.EmptySlices`.
+func (b *builder) EmptySlices(style EmptySliceStyle) Builder {
+	b.emptySlices = style
+	return b
+}
+
+// Registry defines how the Go types registered in `registry` are rendered by the statements created by the
+// builder. It takes precedence over the registry of the dialect (check `WithRegistry`).
+func (b *builder) Registry(registry *Registry) Builder {
+	b.registry = registry
+	return b
+}
+
+// RunWith defines the `Executor` (Ex: `*sql.DB`, `*sql.Tx` or `*sql.Conn`) of the statements created by the
+// builder. Check `Select.RunWith`.
+func (b *builder) RunWith(db Executor) Builder {
+	b.executor = db
+	return b
+}
+
 func (b *builder) Select(fields ...string) Select {
 	return &SelectStatement{
 		placeholderFormat: b.placeholder,
+		quoter:            b.quoter,
+		dialect:           b.dialect,
+		strict:            b.strict,
+		emptySlices:       b.emptySlices,
+		registry:          b.registry,
+		executor:          b.executor,
 	}
 }
 
 func (b *builder) Insert(into string, fields ...interface{}) Insert {
 	return &InsertStatement{
 		placeholderFormat: b.placeholder,
+		quoter:            b.quoter,
+		dialect:           b.dialect,
+		strict:            b.strict,
+		emptySlices:       b.emptySlices,
+		registry:          b.registry,
+		executor:          b.executor,
 		tableName:         into,
 		fields:            fields,
 	}
@@ -38,6 +97,12 @@ func (b *builder) Delete(tableName ...string) Delete {
 	}
 	return &DeleteStatement{
 		placeholderFormat: b.placeholder,
+		quoter:            b.quoter,
+		dialect:           b.dialect,
+		strict:            b.strict,
+		emptySlices:       b.emptySlices,
+		registry:          b.registry,
+		executor:          b.executor,
 		from:              t,
 		as:                as,
 	}
@@ -53,6 +118,12 @@ func (b *builder) Update(tableName ...string) Update {
 	}
 	return &UpdateStatement{
 		placeholderFormat: b.placeholder,
+		quoter:            b.quoter,
+		dialect:           b.dialect,
+		strict:            b.strict,
+		emptySlices:       b.emptySlices,
+		registry:          b.registry,
+		executor:          b.executor,
 		tableName:         t,
 		as:                as,
 	}
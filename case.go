@@ -0,0 +1,125 @@
+package sqlf
+
+import "errors"
+
+var (
+	sqlCaseClause = []byte("CASE")
+	sqlCaseWhen   = []byte(" WHEN ")
+	sqlCaseThen   = []byte(" THEN ")
+	sqlCaseElse   = []byte(" ELSE ")
+	sqlCaseEnd    = []byte(" END")
+)
+
+var (
+	// ErrCaseWithoutWhen is returned when a CASE expression is rendered without any WHEN branch.
+	ErrCaseWithoutWhen = errors.New("the CASE expression must have at least one WHEN branch")
+)
+
+// CaseWhen represents a SQL CASE expression.
+type CaseWhen interface {
+	FastSqlizer
+
+	// When adds a WHEN ... THEN ... branch.
+	//
+	// For the searched form (`Case()`), `condition` is rendered as SQL, so it accepts strings and any `FastSqlizer`
+	// (`And`, `Or`, `Condition`, etc). For the simple form (`Case(value)`), `condition` is the value that will be
+	// compared and it is rendered as an argument.
+	//
+	// `value` is always rendered as an argument. Use a `FastSqlizer` for expressions.
+	When(condition interface{}, value interface{}) CaseWhen
+
+	// Else defines the ELSE branch. `value` is rendered as an argument.
+	Else(value interface{}) CaseWhen
+}
+
+type caseWhenBranch struct {
+	condition interface{}
+	value     interface{}
+}
+
+// CaseClause is the default implementation of the `CaseWhen` interface.
+type CaseClause struct {
+	value     interface{}
+	whens     []caseWhenBranch
+	elseValue interface{}
+	hasElse   bool
+}
+
+// Case creates a new CASE expression.
+//
+// When no `value` is given, the searched form is used:
+//
+//     CASE WHEN <condition> THEN <value> ... ELSE <value> END
+//
+// Otherwise, the simple form is used and the first `value` is rendered as SQL:
+//
+//     CASE <value> WHEN <value> THEN <value> ... ELSE <value> END
+func Case(value ...interface{}) CaseWhen {
+	c := &CaseClause{}
+	if len(value) > 0 {
+		c.value = value[0]
+	}
+	return c
+}
+
+// When adds a WHEN ... THEN ... branch.
+func (c *CaseClause) When(condition interface{}, value interface{}) CaseWhen {
+	c.whens = append(c.whens, caseWhenBranch{
+		condition: condition,
+		value:     value,
+	})
+	return c
+}
+
+// Else defines the ELSE branch.
+func (c *CaseClause) Else(value interface{}) CaseWhen {
+	c.elseValue = value
+	c.hasElse = true
+	return c
+}
+
+// ToSQLFast generates the SQL and returns it, alongside its params.
+func (c *CaseClause) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
+	if len(c.whens) == 0 {
+		return ErrCaseWithoutWhen
+	}
+
+	sb.Write(sqlCaseClause)
+	if c.value != nil {
+		sb.Write(sqlSpace)
+		err := RenderInterfaceAsSQL(sb, args, c.value)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, when := range c.whens {
+		sb.Write(sqlCaseWhen)
+		var err error
+		if c.value != nil {
+			// Simple form: the WHEN part is a value that is compared to the CASE value.
+			err = RenderInterfaceAsArg(sb, args, when.condition)
+		} else {
+			err = RenderInterfaceAsSQL(sb, args, when.condition)
+		}
+		if err != nil {
+			return err
+		}
+		sb.Write(sqlCaseThen)
+		err = RenderInterfaceAsArg(sb, args, when.value)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.hasElse {
+		sb.Write(sqlCaseElse)
+		err := RenderInterfaceAsArg(sb, args, c.elseValue)
+		if err != nil {
+			return err
+		}
+	}
+
+	sb.Write(sqlCaseEnd)
+	return nil
+}
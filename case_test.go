@@ -0,0 +1,100 @@
+package sqlf_test
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/jamillosantos/sqlf"
+	"github.com/jamillosantos/sqlf/testingutils"
+)
+
+var _ = Describe("Case", func() {
+	It("should generate a searched CASE", func() {
+		sb, args := new(strings.Builder), make([]interface{}, 0)
+		err := sqlf.Case().
+			When(sqlf.Condition("status = ?", "active"), 1).
+			When(sqlf.And(sqlf.Condition("status = ?", "pending"), sqlf.Condition("age > ?", 18)), 2).
+			Else(3).
+			ToSQLFast(sb, &args)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(Equal([]interface{}{"active", 1, "pending", 18, 2, 3}))
+		Expect(sb.String()).To(Equal("CASE WHEN status = ? THEN ? WHEN (status = ? AND age > ?) THEN ? ELSE ? END"))
+	})
+
+	It("should generate a simple CASE", func() {
+		sb, args := new(strings.Builder), make([]interface{}, 0)
+		err := sqlf.Case("status").
+			When("active", 1).
+			When("pending", 2).
+			ToSQLFast(sb, &args)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(Equal([]interface{}{"active", 1, "pending", 2}))
+		Expect(sb.String()).To(Equal("CASE status WHEN ? THEN ? WHEN ? THEN ? END"))
+	})
+
+	It("should generate a CASE with expressions as values", func() {
+		sb, args := new(strings.Builder), make([]interface{}, 0)
+		err := sqlf.Case().
+			When("amount > 0", sqlf.Condition("amount")).
+			Else(sqlf.Condition("NULL")).
+			ToSQLFast(sb, &args)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(BeEmpty())
+		Expect(sb.String()).To(Equal("CASE WHEN amount > 0 THEN amount ELSE NULL END"))
+	})
+
+	It("should fail generating a CASE without WHEN", func() {
+		sb, args := new(strings.Builder), make([]interface{}, 0)
+		err := sqlf.Case().Else(1).ToSQLFast(sb, &args)
+		Expect(err).To(Equal(sqlf.ErrCaseWithoutWhen))
+	})
+
+	It("should fail generating a CASE with an errored condition", func() {
+		sb, args := new(strings.Builder), make([]interface{}, 0)
+		err := sqlf.Case().When(&testingutils.MockerSqlizer{
+			Err: errors.New("forced error"),
+		}, 1).ToSQLFast(sb, &args)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("forced error"))
+	})
+
+	It("should fail generating a CASE with an errored value", func() {
+		sb, args := new(strings.Builder), make([]interface{}, 0)
+		err := sqlf.Case().When("a = 1", 1).Else(&testingutils.MockerSqlizer{
+			Err: errors.New("forced error"),
+		}).ToSQLFast(sb, &args)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("forced error"))
+	})
+
+	It("should be used as a select field, order by and group by", func() {
+		s := new(sqlf.SelectStatement)
+		sql, args, err := s.
+			Select("id", sqlf.Case().When("score > 10", "high").Else("low")).
+			From("users").
+			GroupBy(sqlf.Case("kind").When("a", 1).Else(2)).
+			OrderByX(func(orderBy sqlf.OrderBy) {
+				orderBy.Desc(sqlf.Case().When(sqlf.Condition("role = ?", "admin"), 0).Else(1))
+			}).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(Equal([]interface{}{"high", "low", "a", 1, 2, "admin", 0, 1}))
+		Expect(sql).To(Equal("SELECT id, CASE WHEN score > 10 THEN ? ELSE ? END FROM users GROUP BY CASE kind WHEN ? THEN ? ELSE ? END ORDER BY CASE WHEN role = ? THEN ? ELSE ? END DESC"))
+	})
+
+	It("should be used as an update value", func() {
+		u := new(sqlf.UpdateStatement)
+		sql, args, err := u.
+			Placeholder(sqlf.DollarPlaceholder).
+			Table("users").
+			Set("level", sqlf.Case().When(sqlf.Condition("points > ?", 100), "gold").Else("silver")).
+			Where("id = ?", 1).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(Equal([]interface{}{100, "gold", "silver", 1}))
+		Expect(sql).To(Equal("UPDATE users SET level = CASE WHEN points > $1 THEN $2 ELSE $3 END WHERE id = $4"))
+	})
+})
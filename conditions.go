@@ -1,16 +1,122 @@
 package sqlf
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	sqlSpace      = []byte(" ")
+	sqlEmptySlice = []byte("NULL")
+)
+
 var (
-	sqlSpace = []byte(" ")
+	// ErrPlaceholderMismatch is the error matched, through `errors.Is`, by any `PlaceholderMismatchError`.
+	ErrPlaceholderMismatch = errors.New("placeholder count does not match the args count")
+)
+
+// PlaceholderMismatchError is returned when the number of `?` placeholders of a `Condition` (excluding the `??`
+// escapes and the `?` inside of string literals, quoted identifiers and comments) does not match the number of its
+// args. When rendered as part of a statement, it is wrapped by a `RenderError` naming the clause and the position
+// of the condition.
+type PlaceholderMismatchError struct {
+	SQL          string
+	Placeholders int
+	Args         int
+}
+
+// Error implements the `error` interface.
+func (err *PlaceholderMismatchError) Error() string {
+	return fmt.Sprintf("sqlf: condition %q has %d placeholders but %d args", err.SQL, err.Placeholders, err.Args)
+}
+
+// Is makes `errors.Is(err, ErrPlaceholderMismatch)` match.
+func (err *PlaceholderMismatchError) Is(target error) bool {
+	return target == ErrPlaceholderMismatch
+}
+
+var (
+	// AlwaysTrue is a condition that always evaluates to true.
+	AlwaysTrue = Condition("(1=1)")
+
+	// AlwaysFalse is a condition that always evaluates to false.
+	AlwaysFalse = Condition("(1=0)")
+)
+
+var (
+	// ErrEmptySlice is returned when a slice argument of a `Condition` is empty and the statement uses
+	// `EmptySliceError`.
+	ErrEmptySlice = errors.New("cannot expand an empty slice argument")
+)
+
+// EmptySliceStyle defines how a `Condition` renders a slice argument that is empty (check `Select.EmptySlices`).
+// An empty slice cannot be expanded into a valid `IN (...)` list.
+type EmptySliceStyle int
+
+const (
+	// emptySliceDefault is the style of the statements that do not define one. It inherits the style of the
+	// statement it is nested into, falling back to `EmptySlicePredicate`.
+	emptySliceDefault EmptySliceStyle = iota
+
+	// EmptySliceNull renders `NULL` in place of the `?` bound to the empty slice, keeping the rest of the condition.
+	// So, `id IN (?)` renders `id IN (NULL)`, which matches no rows. Beware `id NOT IN (NULL)` matches no rows
+	// either.
+	EmptySliceNull
+
+	// EmptySliceError fails the rendering with `ErrEmptySlice`.
+	EmptySliceError
+
+	// EmptySlicePredicate replaces the predicate of the `?` bound to the empty slice, keeping the rest of the
+	// condition: `id IN (?)` renders the always false `(1=0)` and `id NOT IN (?)` the always true `(1=1)`, as `In`
+	// and `NotIn` do. The column must be a name, as `users.id` or `"id"`, otherwise the `?` renders `NULL`, as
+	// `EmptySliceNull` does. It is the default.
+	EmptySlicePredicate
 )
 
 type condition struct {
 	sql  string
 	args []interface{}
+	// placeholders is the number of `?` in the SQL code, not counting the `??` escapes.
+	placeholders int
+	// expand is true when at least one of the args is a slice that should be expanded into multiple placeholders.
+	expand bool
+	// named are the `:name` parameters of the sql. Check `Select.Bind`.
+	named []namedParameter
+	// syntaxSensitive is true when the sql has bytes (`[` or `\`) that are quoted or escaped by some dialects, so
+	// `placeholders` and `named` must be found again when rendered for them. Check `lexerSyntax`.
+	syntaxSensitive bool
 }
 
 // ToSQL generates the SQL and returns it, alongside its params.
 func (condition *condition) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
+	err := checkRawSQL(sb, condition.sql)
+	if err != nil {
+		return err
+	}
+	placeholders, named, syntax := condition.placeholders, condition.named, lexerSyntax(0)
+	if condition.syntaxSensitive {
+		syntax = lexerSyntaxOfWriter(sb)
+		if syntax != 0 {
+			placeholders, named = countPlaceholders(condition.sql, syntax), parseNamedParameters(condition.sql, syntax)
+		}
+	}
+	if placeholders != len(condition.args) {
+		return &PlaceholderMismatchError{
+			SQL:          condition.sql,
+			Placeholders: placeholders,
+			Args:         len(condition.args),
+		}
+	}
+	if len(named) > 0 {
+		// Without bindings, the names are not parameters (Ex: sqlx queries), so the sql is rendered as it is.
+		if bindings := bindingsOf(sb); bindings != nil {
+			return condition.toSQLNamed(sb, args, bindings, syntax)
+		}
+	}
+	if condition.expand {
+		return condition.toSQLExpanded(sb, args, syntax)
+	}
 	sb.WriteString(condition.sql)
 	if len(condition.args) > 0 {
 		*args = append(*args, condition.args...)
@@ -18,9 +124,339 @@ func (condition *condition) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
 	return nil
 }
 
+// toSQLExpanded renders the condition replacing each `?` that is bound to a slice by a list of placeholders, one
+// for each element of the slice. `syntax` are the lexer rules of the statement.
+func (condition *condition) toSQLExpanded(sb SQLWriter, args *[]interface{}, syntax lexerSyntax) error {
+	lexer := sqlLexer{syntax: syntax}
+	sql := condition.sql
+	argIdx, lastW := 0, 0
+	for i := 0; i < len(sql); i++ {
+		if !lexer.next(sql[i]) || sql[i] != '?' {
+			continue
+		}
+		if i+1 < len(sql) && sql[i+1] == '?' {
+			// Escaped `??`, it is kept as it is for the placeholder format to handle.
+			i++
+			continue
+		}
+		if argIdx >= len(condition.args) {
+			break
+		}
+		arg := condition.args[argIdx]
+		argIdx++
+		values, ok := expandSlice(arg)
+		if !ok {
+			*args = append(*args, arg)
+			continue
+		}
+		if len(values) == 0 {
+			next, err := writeEmptySlice(sb, sql, lastW, i, i+1)
+			if err != nil {
+				return err
+			}
+			for ; i+1 < next; i++ {
+				lexer.next(sql[i+1])
+			}
+			lastW = next
+			continue
+		}
+		sb.WriteString(sql[lastW:i])
+		writeExpandedPlaceholders(sb, args, values)
+		lastW = i + 1
+	}
+	if lastW < len(sql) {
+		sb.WriteString(sql[lastW:])
+	}
+	if argIdx < len(condition.args) {
+		*args = append(*args, condition.args[argIdx:]...)
+	}
+	return nil
+}
+
+// toSQLNamed renders the condition replacing each `:name` parameter by a placeholder bound to its value in the
+// `bindings`. The `?` placeholders are rendered as in `toSQLExpanded`.
+func (condition *condition) toSQLNamed(sb SQLWriter, args *[]interface{}, bindings *bindings, syntax lexerSyntax) error {
+	named := condition.named
+	if syntax != 0 {
+		named = parseNamedParameters(condition.sql, syntax)
+	}
+	for _, parameter := range named {
+		if _, ok := bindings.values[parameter.name]; !ok {
+			return &MissingBindingError{
+				Name: parameter.name,
+			}
+		}
+	}
+
+	lexer := sqlLexer{syntax: syntax}
+	sql := condition.sql
+	argIdx, namedIdx, lastW := 0, 0, 0
+	for i := 0; i < len(sql); i++ {
+		if namedIdx < len(named) && named[namedIdx].start == i {
+			name := named[namedIdx].name
+			namedIdx++
+			for j := i; j <= i+len(name); j++ {
+				lexer.next(sql[j])
+			}
+			value := bindings.values[name]
+			values, ok := expandSlice(value)
+			if ok && len(values) == 0 {
+				next, err := writeEmptySlice(sb, sql, lastW, i, i+1+len(name))
+				if err != nil {
+					return err
+				}
+				for i += len(name); i+1 < next; i++ {
+					lexer.next(sql[i+1])
+				}
+				lastW = next
+				continue
+			}
+			sb.WriteString(sql[lastW:i])
+			if ok {
+				writeExpandedPlaceholders(sb, args, values)
+			} else if !writeNamedPlaceholder(sb, name) {
+				*args = append(*args, value)
+			}
+			i += len(name)
+			lastW = i + 1
+			continue
+		}
+		if !lexer.next(sql[i]) || sql[i] != '?' {
+			continue
+		}
+		if i+1 < len(sql) && sql[i+1] == '?' {
+			// Escaped `??`, it is kept as it is for the placeholder format to handle.
+			i++
+			continue
+		}
+		arg := condition.args[argIdx]
+		argIdx++
+		values, ok := expandSlice(arg)
+		if !ok {
+			*args = append(*args, arg)
+			continue
+		}
+		if len(values) == 0 {
+			next, err := writeEmptySlice(sb, sql, lastW, i, i+1)
+			if err != nil {
+				return err
+			}
+			for ; i+1 < next; i++ {
+				lexer.next(sql[i+1])
+			}
+			lastW = next
+			continue
+		}
+		sb.WriteString(sql[lastW:i])
+		writeExpandedPlaceholders(sb, args, values)
+		lastW = i + 1
+	}
+	if lastW < len(sql) {
+		sb.WriteString(sql[lastW:])
+	}
+	return nil
+}
+
+// writeExpandedPlaceholders writes a placeholder for each of the `values`, separated by comma, and binds them.
+func writeExpandedPlaceholders(sb SQLWriter, args *[]interface{}, values []interface{}) {
+	for idx := range values {
+		if idx > 0 {
+			sb.Write(sqlComma)
+		}
+		sb.Write(sqlPredicatePlaceholder)
+	}
+	*args = append(*args, values...)
+}
+
+// writeEmptySlice writes the SQL from `lastW` up to the placeholder at `sql[start:end]`, which is bound to an empty
+// slice, rendering it according to the `EmptySliceStyle` of the statement. It returns where the SQL continues:
+// after the predicate when it is replaced, otherwise after the placeholder.
+func writeEmptySlice(sb SQLWriter, sql string, lastW, start, end int) (int, error) {
+	style := emptySliceStyleOf(sb)
+	if style == EmptySliceError {
+		return 0, ErrEmptySlice
+	}
+	if style == EmptySlicePredicate {
+		from, to, negated, ok := emptySlicePredicate(sql, start, end)
+		if ok && from >= lastW {
+			sb.WriteString(sql[lastW:from])
+			if negated {
+				sb.Write(sqlPredicateAlwaysTrue)
+			} else {
+				sb.Write(sqlPredicateAlwaysFalse)
+			}
+			return to, nil
+		}
+	}
+	sb.WriteString(sql[lastW:start])
+	sb.Write(sqlEmptySlice)
+	return end, nil
+}
+
+// emptySlicePredicateStarts are the keywords that may precede the column of a predicate replaced by
+// `EmptySlicePredicate`. After any other word (Ex: `a + b IN (?)`), the column could be part of an expression.
+var emptySlicePredicateStarts = []string{"AND", "OR", "NOT", "WHERE", "ON", "HAVING", "WHEN", "THEN", "ELSE"}
+
+// emptySlicePredicate finds the `<column> IN (<placeholder>)` or `<column> NOT IN (<placeholder>)` predicate of the
+// placeholder at `sql[start:end]`, returning its bounds and whether it is negated.
+func emptySlicePredicate(sql string, start, end int) (from, to int, negated, ok bool) {
+	to = end
+	for to < len(sql) && isSpace(sql[to]) {
+		to++
+	}
+	if to == len(sql) || sql[to] != ')' {
+		return 0, 0, false, false
+	}
+	to++
+
+	from = trimSpacesBefore(sql, start)
+	if from == 0 || sql[from-1] != '(' {
+		return 0, 0, false, false
+	}
+	from = trimSpacesBefore(sql, from-1)
+	if !hasWordBefore(sql, from, "IN") {
+		return 0, 0, false, false
+	}
+	from = trimSpacesBefore(sql, from-len("IN"))
+	if hasWordBefore(sql, from, "NOT") {
+		negated = true
+		from = trimSpacesBefore(sql, from-len("NOT"))
+	}
+
+	// The column: names separated by dots, each plain or quoted.
+	for {
+		if from == 0 {
+			return 0, 0, false, false
+		}
+		switch c := sql[from-1]; {
+		case c == '"' || c == '`':
+			from = strings.LastIndexByte(sql[:from-1], c)
+			// Doubled quotes are escapes inside of the name.
+			for from > 0 && sql[from-1] == c {
+				from = strings.LastIndexByte(sql[:from-1], c)
+			}
+		case c == ']':
+			from = strings.LastIndexByte(sql[:from-1], '[')
+		case isTagByte(c, false):
+			for from > 0 && isTagByte(sql[from-1], false) {
+				from--
+			}
+		default:
+			return 0, 0, false, false
+		}
+		if from < 0 {
+			return 0, 0, false, false
+		}
+		if from == 0 || sql[from-1] != '.' {
+			break
+		}
+		from--
+	}
+
+	before := trimSpacesBefore(sql, from)
+	if before == 0 || sql[before-1] == '(' {
+		return from, to, negated, true
+	}
+	for _, word := range emptySlicePredicateStarts {
+		if hasWordBefore(sql, before, word) {
+			return from, to, negated, true
+		}
+	}
+	return 0, 0, false, false
+}
+
+// trimSpacesBefore returns the position of `sql[:end]` without its trailing spaces.
+func trimSpacesBefore(sql string, end int) int {
+	for end > 0 && isSpace(sql[end-1]) {
+		end--
+	}
+	return end
+}
+
+// isSpace reports whether `c` is a whitespace of the SQL code.
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// hasWordBefore reports whether `sql[:end]` ends with the whole `word`, ignoring the case.
+func hasWordBefore(sql string, end int, word string) bool {
+	start := end - len(word)
+	if start < 0 || !strings.EqualFold(sql[start:end], word) {
+		return false
+	}
+	return start == 0 || !isTagByte(sql[start-1], false)
+}
+
+// emptySliceStyleOf returns the `EmptySliceStyle` of the statement being rendered.
+func emptySliceStyleOf(sb SQLWriter) EmptySliceStyle {
+	if ctx, ok := contextOf(sb); ok && ctx.emptySlices != emptySliceDefault {
+		return ctx.emptySlices
+	}
+	return EmptySlicePredicate
+}
+
+// Condition creates a condition based on a plain SQL and its args.
+//
+// Any slice argument (except `[]byte`) is expanded into a list of placeholders at render time. So,
+// `Condition("id IN (?)", []int{1, 2, 3})` renders `id IN (?, ?, ?)`. An empty slice renders according to the
+// `EmptySliceStyle` of the statement (check `Select.EmptySlices`): `id IN (?)` renders `(1=0)` by default.
+//
+// The number of `?` (not counting the `??` escapes nor the `?` inside of string literals, quoted identifiers and
+// comments) must match the number of `args`, otherwise rendering fails with a `PlaceholderMismatchError`.
+//
+// The sql may also use named parameters (Ex: `created_at > :since`), bound later by the statement (check
+// `Select.Bind`). Each name is rendered as a placeholder of the statement format, reusing the same placeholder when
+// the name appears twice and the format is numbered (Ex: `$1` for `DollarPlaceholder`). Casts (`::`), array slices
+// (Ex: `tags[lo:hi]`) and names inside string literals are not parameters. Rendering fails with a
+// `MissingBindingError` when a name is not bound. When the statement has no bindings, the names are rendered as
+// they are.
 func Condition(sql string, args ...interface{}) FastSqlizer {
+	expand := false
+	for _, arg := range args {
+		if isExpandableSlice(arg) {
+			expand = true
+			break
+		}
+	}
 	return &condition{
-		sql:  sql,
-		args: args,
+		sql:             sql,
+		args:            args,
+		placeholders:    countPlaceholders(sql, 0),
+		expand:          expand,
+		named:           parseNamedParameters(sql, 0),
+		syntaxSensitive: strings.ContainsAny(sql, `[\`),
+	}
+}
+
+// countPlaceholders returns the number of `?` in the SQL code of `sql`, not counting the `??` escapes nor the `?`
+// inside of string literals, quoted identifiers and comments. `syntax` are the lexer rules of the dialect.
+func countPlaceholders(sql string, syntax lexerSyntax) int {
+	lexer := sqlLexer{syntax: syntax}
+	count := 0
+	for i := 0; i < len(sql); i++ {
+		if !lexer.next(sql[i]) || sql[i] != '?' {
+			continue
+		}
+		if i+1 < len(sql) && sql[i+1] == '?' {
+			i++
+			continue
+		}
+		count++
 	}
+	return count
+}
+
+// writeConditions writes the `conditions` of the `clause` joined by the AND operator. Errors are wrapped into a
+// `RenderError` naming the `clause` and the position of the condition.
+func writeConditions(sb SQLWriter, args *[]interface{}, statement, clause string, conditions []FastSqlizer) error {
+	for idx, condition := range conditions {
+		if idx > 0 {
+			sb.Write(sqlConditionAnd)
+		}
+		err := RenderInterfaceAsSQL(sb, args, condition)
+		if err != nil {
+			return renderError(statement, clauseAt(clause, idx), condition, err)
+		}
+	}
+	return nil
 }
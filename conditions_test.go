@@ -0,0 +1,208 @@
+package sqlf_test
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/jamillosantos/sqlf"
+)
+
+var _ = Describe("Condition", func() {
+	It("should generate a condition", func() {
+		sb, args := new(strings.Builder), make([]interface{}, 0)
+		err := sqlf.Condition("id = ? AND age >= ?", 1, 18).ToSQLFast(sb, &args)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(Equal([]interface{}{1, 18}))
+		Expect(sb.String()).To(Equal("id = ? AND age >= ?"))
+	})
+
+	Describe("Slice expansion", func() {
+		It("should expand a slice argument", func() {
+			sb, args := new(strings.Builder), make([]interface{}, 0)
+			err := sqlf.Condition("id IN (?)", []int{1, 2, 3}).ToSQLFast(sb, &args)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(args).To(Equal([]interface{}{1, 2, 3}))
+			Expect(sb.String()).To(Equal("id IN (?, ?, ?)"))
+		})
+
+		It("should expand a slice argument mixed with other arguments", func() {
+			sb, args := new(strings.Builder), make([]interface{}, 0)
+			err := sqlf.Condition("account_id = ? AND id IN (?) AND name LIKE ?", 7, []string{"a", "b"}, "J%").ToSQLFast(sb, &args)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(args).To(Equal([]interface{}{7, "a", "b", "J%"}))
+			Expect(sb.String()).To(Equal("account_id = ? AND id IN (?, ?) AND name LIKE ?"))
+		})
+
+		It("should not expand []byte", func() {
+			sb, args := new(strings.Builder), make([]interface{}, 0)
+			err := sqlf.Condition("hash = ? AND id IN (?)", []byte("abc"), []int{1, 2}).ToSQLFast(sb, &args)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(args).To(Equal([]interface{}{[]byte("abc"), 1, 2}))
+			Expect(sb.String()).To(Equal("hash = ? AND id IN (?, ?)"))
+		})
+
+		It("should skip escaped placeholders", func() {
+			sb, args := new(strings.Builder), make([]interface{}, 0)
+			err := sqlf.Condition("data ?? 'key' AND id IN (?)", []int{1, 2}).ToSQLFast(sb, &args)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(args).To(Equal([]interface{}{1, 2}))
+			Expect(sb.String()).To(Equal("data ?? 'key' AND id IN (?, ?)"))
+		})
+
+		It("should render an always false IN for an empty slice, keeping the rest of the condition", func() {
+			sb, args := new(strings.Builder), make([]interface{}, 0)
+			err := sqlf.Condition("account_id = ? OR users.id IN (?) OR name = ?", 7, []int{}, "john").ToSQLFast(sb, &args)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(args).To(Equal([]interface{}{7, "john"}))
+			Expect(sb.String()).To(Equal("account_id = ? OR (1=0) OR name = ?"))
+		})
+
+		It("should render an always true NOT IN for an empty slice", func() {
+			sql, args, err := new(sqlf.SelectStatement).
+				Placeholder(sqlf.DollarPlaceholder).
+				From("users").
+				Where(`role = ? AND (id NOT IN (?) OR "users"."id" not in ( ? ))`, "admin", []int{}, []int64{}).
+				ToSQL()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(args).To(Equal([]interface{}{"admin"}))
+			Expect(sql).To(Equal("SELECT * FROM users WHERE role = $1 AND ((1=1) OR (1=1))"))
+		})
+
+		It("should render NULL for an empty slice that is not bound to a column", func() {
+			sb, args := new(strings.Builder), make([]interface{}, 0)
+			err := sqlf.Condition("a + b IN (?) OR lower(name) IN (?) OR id = ANY(?)", []int{}, []string{}, []int{}).ToSQLFast(sb, &args)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(args).To(BeEmpty())
+			Expect(sb.String()).To(Equal("a + b IN (NULL) OR lower(name) IN (NULL) OR id = ANY(NULL)"))
+		})
+
+		It("should render NULL for an empty slice when the statement uses EmptySliceNull", func() {
+			sql, _, err := new(sqlf.SelectStatement).
+				From("users").
+				EmptySlices(sqlf.EmptySliceNull).
+				Where("id IN (?) OR id NOT IN (?)", []int{}, []int{}).
+				ToSQL()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sql).To(Equal("SELECT * FROM users WHERE id IN (NULL) OR id NOT IN (NULL)"))
+		})
+
+		It("should fail for an empty slice when the statement uses EmptySliceError", func() {
+			_, _, err := new(sqlf.SelectStatement).
+				From("users").
+				EmptySlices(sqlf.EmptySliceError).
+				Where("id NOT IN (?)", []int{}).
+				ToSQL()
+			Expect(err).To(MatchError(`sqlf: SELECT WHERE #1 "id NOT IN (?)": cannot expand an empty slice argument`))
+			Expect(errors.Is(err, sqlf.ErrEmptySlice)).To(BeTrue())
+		})
+
+		It("should inherit the empty slice style of the outer statement", func() {
+			_, _, err := sqlf.NewBuilder().
+				EmptySlices(sqlf.EmptySliceError).
+				Select().
+				From("users").
+				WhereCriteria(sqlf.Exists(new(sqlf.SelectStatement).From("roles").Where("id IN (?)", []int{}))).
+				ToSQL()
+			Expect(errors.Is(err, sqlf.ErrEmptySlice)).To(BeTrue())
+		})
+
+		It("should number expanded placeholders with the dollar placeholder", func() {
+			sql, args, err := new(sqlf.SelectStatement).
+				Placeholder(sqlf.DollarPlaceholder).
+				From("users").
+				Where("account_id = ?", 7).
+				Where("id IN (?)", []int64{1, 2, 3}).
+				Where("age >= ?", 18).
+				ToSQL()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(args).To(Equal([]interface{}{7, int64(1), int64(2), int64(3), 18}))
+			Expect(sql).To(Equal("SELECT * FROM users WHERE account_id = $1 AND id IN ($2, $3, $4) AND age >= $5"))
+		})
+
+		It("should expand slices on insert suffixes", func() {
+			insert := new(sqlf.InsertStatement)
+			sql, args, err := insert.Into("users", "name").Values("Name 1").Suffix("ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name WHERE users.role IN (?)", []string{"a", "b"}).ToSQL()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(args).To(Equal([]interface{}{"Name 1", "a", "b"}))
+			Expect(sql).To(Equal("INSERT INTO users (name) VALUES (?) ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name WHERE users.role IN (?, ?)"))
+		})
+	})
+
+	Describe("placeholder validation", func() {
+		It("should fail when there are more placeholders than args", func() {
+			sb, args := new(strings.Builder), make([]interface{}, 0)
+			err := sqlf.Condition("a = ? AND b = ?", 1).ToSQLFast(sb, &args)
+			Expect(errors.Is(err, sqlf.ErrPlaceholderMismatch)).To(BeTrue())
+			Expect(err).To(MatchError(`sqlf: condition "a = ? AND b = ?" has 2 placeholders but 1 args`))
+		})
+
+		It("should fail when there are more args than placeholders", func() {
+			sb, args := new(strings.Builder), make([]interface{}, 0)
+			err := sqlf.Condition("a = 1", 1).ToSQLFast(sb, &args)
+			Expect(errors.Is(err, sqlf.ErrPlaceholderMismatch)).To(BeTrue())
+		})
+
+		It("should not count escaped placeholders", func() {
+			sb, args := new(strings.Builder), make([]interface{}, 0)
+			err := sqlf.Condition("data ?? 'key' AND id = ?", 1).ToSQLFast(sb, &args)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should not count placeholders inside of literals, quoted identifiers and comments", func() {
+			sb, args := new(strings.Builder), make([]interface{}, 0)
+			err := sqlf.Condition(`name <> 'who?' AND "why?" = ? /* how? */`, 1).ToSQLFast(sb, &args)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(args).To(Equal([]interface{}{1}))
+		})
+
+		It("should count the placeholders after identifiers with a dollar", func() {
+			sb, args := new(strings.Builder), make([]interface{}, 0)
+			err := sqlf.Condition("sys$user$ = ? AND id IN (?)", 1, []int{2, 3}).ToSQLFast(sb, &args)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sb.String()).To(Equal("sys$user$ = ? AND id IN (?, ?)"))
+			Expect(args).To(Equal([]interface{}{1, 2, 3}))
+		})
+
+		It("should name the clause and the position of the condition", func() {
+			_, _, err := new(sqlf.SelectStatement).
+				From("users").
+				Where("id = ?", 1).
+				Where("age >= ? AND age <= ?", 18).
+				ToSQL()
+			var mismatch *sqlf.PlaceholderMismatchError
+			Expect(errors.As(err, &mismatch)).To(BeTrue())
+			Expect(*mismatch).To(Equal(sqlf.PlaceholderMismatchError{
+				SQL:          "age >= ? AND age <= ?",
+				Placeholders: 2,
+				Args:         1,
+			}))
+			var renderErr *sqlf.RenderError
+			Expect(errors.As(err, &renderErr)).To(BeTrue())
+			Expect(renderErr.Statement).To(Equal("SELECT"))
+			Expect(renderErr.Clause).To(Equal("WHERE #2"))
+			Expect(err).To(MatchError(`sqlf: SELECT WHERE #2 "age >= ? AND age <= ?": condition "age >= ? AND age <= ?" has 2 placeholders but 1 args`))
+		})
+
+		It("should name the clause of a nested statement", func() {
+			_, _, err := new(sqlf.SelectStatement).
+				From("users").
+				GroupByX(func(g sqlf.GroupBy) {
+					g.Fields("role").HavingClause(sqlf.Gt(sqlf.Count(), 1), sqlf.Exists(new(sqlf.SelectStatement).From("roles").InnerJoin("grants", "g").On("g.role = ?")))
+				}).
+				ToSQL()
+			Expect(errors.Is(err, sqlf.ErrPlaceholderMismatch)).To(BeTrue())
+			Expect(err).To(MatchError(`sqlf: SELECT GROUP BY > HAVING #2 > SELECT JOIN #1 > ON #1 "g.role = ?": condition "g.role = ?" has 1 placeholders but 0 args`))
+		})
+
+		It("should name the suffix of an insert", func() {
+			_, _, err := new(sqlf.InsertStatement).Into("users", "name").Values("Name 1").Suffix("ON CONFLICT DO NOTHING", 1).ToSQL()
+			var renderErr *sqlf.RenderError
+			Expect(errors.As(err, &renderErr)).To(BeTrue())
+			Expect(renderErr.Clause).To(Equal("SUFFIX"))
+			Expect(errors.Is(err, sqlf.ErrPlaceholderMismatch)).To(BeTrue())
+		})
+	})
+})
@@ -3,11 +3,34 @@ package sqlf
 // Delete describes how a DELETE will behave into the sqlf.
 type Delete interface {
 	Sqlizer
+	InterpolatedSqlizer
+	Runner
 	FastSqlizer
 
 	// Placeholder defines the placeholder format that should be used for this delete statement.
 	Placeholder(placeholder PlaceholderFormatFactory) Delete
 
+	// QuoteIdentifiers enables quoting the table and alias of the delete automatically, using `quoter`. The `quoter`
+	// is also used for the `Identifier`s rendered as part of the delete.
+	QuoteIdentifiers(quoter IdentifierQuoter) Delete
+
+	// Dialect defines the database technology the delete is rendered for. The dialect provides the placeholder
+	// format (when `Placeholder` is not defined), the identifier quoting and the syntax of the features that differ
+	// between databases. Using a feature the dialect does not support returns an `UnsupportedFeatureError`.
+	Dialect(dialect Dialect) Delete
+
+	// Strict enables the strict mode for the delete, and the statements nested into it. Check `Select.Strict`.
+	Strict() Delete
+
+	// EmptySlices defines how the empty slice arguments of the conditions are rendered. Check `Select.EmptySlices`.
+	EmptySlices(style EmptySliceStyle) Delete
+
+	// Bind defines the values of the named parameters of the conditions. Check `Select.Bind`.
+	Bind(values interface{}) Delete
+
+	// RunWith defines the `Executor` used to run the delete. Check `Select.RunWith`.
+	RunWith(db Executor) Delete
+
 	// Cascade enables the CASCADE option.
 	Cascade() Delete
 
@@ -25,6 +48,6 @@ type Delete interface {
 	WhereClause(conditions ...FastSqlizer) Delete
 
 	// Suffix adds a suffix to the DELETE statement. That can be useful for
-	// extending the SQL for uncovered database technologies.
-	Suffix(suffix string) Delete
+	// extending the SQL for uncovered database technologies. The `args` are bound as in `Condition`.
+	Suffix(suffix string, args ...interface{}) Delete
 }
@@ -1,6 +1,9 @@
 package sqlf
 
-import "strings"
+import (
+	"context"
+	"database/sql"
+)
 
 var (
 	sqlDeleteStatement        = []byte("DELETE FROM ")
@@ -9,11 +12,18 @@ var (
 
 type DeleteStatement struct {
 	placeholderFormat PlaceholderFormatFactory
+	quoter            IdentifierQuoter
+	dialect           Dialect
+	strict            bool
+	emptySlices       EmptySliceStyle
+	bindings          *bindings
+	registry          *Registry
+	executor          Executor
 	cascade           bool
 	from              string
 	as                string
 	where             []FastSqlizer
-	suffix            string
+	suffix            FastSqlizer
 }
 
 // Placeholder defines the placeholder format that should be used for this delete statement.
@@ -22,6 +32,60 @@ func (d *DeleteStatement) Placeholder(placeholder PlaceholderFormatFactory) Dele
 	return d
 }
 
+// QuoteIdentifiers enables quoting the table and alias of the delete automatically, using `quoter`. The `quoter`
+// is also used for the `Identifier`s rendered as part of the delete.
+func (d *DeleteStatement) QuoteIdentifiers(quoter IdentifierQuoter) Delete {
+	d.quoter = quoter
+	return d
+}
+
+// Dialect defines the database technology the delete is rendered for. The dialect provides the placeholder
+// format (when `Placeholder` is not defined), the identifier quoting and the syntax of the features that differ
+// between databases. Using a feature the dialect does not support returns an `UnsupportedFeatureError`.
+func (d *DeleteStatement) Dialect(dialect Dialect) Delete {
+	d.dialect = dialect
+	return d
+}
+
+// Strict enables the strict mode for the delete, and the statements nested into it. Check `Select.Strict`.
+func (d *DeleteStatement) Strict() Delete {
+	d.strict = true
+	return d
+}
+
+// EmptySlices defines how the empty slice arguments of the conditions are rendered. Check `Select.EmptySlices`.
+func (d *DeleteStatement) EmptySlices(style EmptySliceStyle) Delete {
+	d.emptySlices = style
+	return d
+}
+
+// Bind defines the values of the named parameters of the conditions. Check `Select.Bind`.
+func (d *DeleteStatement) Bind(values interface{}) Delete {
+	d.bindings = newBindings(values)
+	return d
+}
+
+// RunWith defines the `Executor` used to run the delete. Check `Select.RunWith`.
+func (d *DeleteStatement) RunWith(db Executor) Delete {
+	d.executor = db
+	return d
+}
+
+// ExecContext executes the delete with its `Executor`, without returning any rows.
+func (d *DeleteStatement) ExecContext(ctx context.Context) (sql.Result, error) {
+	return execContext(ctx, d.executor, d)
+}
+
+// QueryContext executes the delete with its `Executor`, returning its rows.
+func (d *DeleteStatement) QueryContext(ctx context.Context) (*sql.Rows, error) {
+	return queryContext(ctx, d.executor, d)
+}
+
+// QueryRowContext executes the delete with its `Executor`, returning at most one row. Check `Row`.
+func (d *DeleteStatement) QueryRowContext(ctx context.Context) *Row {
+	return queryRowContext(ctx, d.executor, d)
+}
+
 // Cascade enables the CASCADE option.
 func (d *DeleteStatement) Cascade() Delete {
 	d.cascade = true
@@ -66,15 +130,19 @@ func (d *DeleteStatement) WhereClause(conditions ...FastSqlizer) Delete {
 }
 
 // Suffix adds a suffix to the DELETE statement. That can be useful for
-// extending the SQL for uncovered database technologies.
-func (d *DeleteStatement) Suffix(suffix string) Delete {
-	d.suffix = suffix
+// extending the SQL for uncovered database technologies. The `args` are bound as in `Condition`.
+func (d *DeleteStatement) Suffix(suffix string, args ...interface{}) Delete {
+	if suffix == "" {
+		d.suffix = nil
+		return d
+	}
+	d.suffix = Condition(suffix, args...)
 	return d
 }
 
 // ToSQL generates the SQL and returns it, alongside its params.
 func (d *DeleteStatement) ToSQL() (string, []interface{}, error) {
-	var sb SQLWriter = new(strings.Builder)
+	sb := new(renderBuilder)
 	args := make([]interface{}, 0)
 	err := d.ToSQLFast(sb, &args)
 	if err != nil {
@@ -83,38 +151,67 @@ func (d *DeleteStatement) ToSQL() (string, []interface{}, error) {
 	return sb.String(), args, nil
 }
 
+// ToInterpolatedSQL generates the SQL with its params inlined as literals, for logging and debugging. Check
+// `InterpolatedSQL`.
+func (d *DeleteStatement) ToInterpolatedSQL() (InterpolatedSQL, error) {
+	return ToInterpolatedSQL(d)
+}
+
+// statementDialect returns the dialect of the delete, used to write the literals of `ToInterpolatedSQL`.
+func (d *DeleteStatement) statementDialect() Dialect {
+	return d.dialect
+}
+
 // ToSQLFast generates the SQL and returns it, alongside its params.
 func (d *DeleteStatement) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
-	if d.placeholderFormat != nil {
-		sb = d.placeholderFormat.Wrap(sb)
-	}
+	session := beginRender(sb, args, d.placeholderFormat, renderContext{
+		dialect:     d.dialect,
+		quoter:      d.quoter,
+		quoteAll:    d.quoter != nil,
+		strict:      d.strict,
+		emptySlices: d.emptySlices,
+		bindings:    d.bindings,
+		registry:    d.registry,
+	})
+	return session.end(d.render(session.sb, args))
+}
 
+// render writes the delete into the already prepared `sb`.
+func (d *DeleteStatement) render(sb SQLWriter, args *[]interface{}) error {
+	err := d.bindings.bindError()
+	if err != nil {
+		return renderError("DELETE", "BIND", nil, err)
+	}
 	if d.cascade {
 		sb.Write(sqlDeleteCascadeStatement)
 	} else {
 		sb.Write(sqlDeleteStatement)
 	}
-	sb.WriteString(d.from)
+	err = writeTable(sb, d.from)
+	if err != nil {
+		return renderError("DELETE", "FROM", d.from, err)
+	}
 	if d.as != "" {
 		sb.Write(sqlSelectAsClause)
-		sb.WriteString(d.as)
+		err := writeAlias(sb, d.as)
+		if err != nil {
+			return renderError("DELETE", "AS", d.as, err)
+		}
 	}
 	if len(d.where) > 0 {
 		sb.Write(sqlWhereClause)
-		for idx, condition := range d.where {
-			if idx > 0 {
-				sb.Write(sqlConditionAnd)
-			}
-			err := RenderInterfaceAsSQL(sb, args, condition)
-			if err != nil {
-				return err
-			}
+		err := writeConditions(sb, args, "DELETE", "WHERE", d.where)
+		if err != nil {
+			return err
 		}
 	}
 
-	if d.suffix != "" {
+	if d.suffix != nil {
 		sb.Write(sqlSpace)
-		sb.WriteString(d.suffix)
+		err := d.suffix.ToSQLFast(sb, args)
+		if err != nil {
+			return renderError("DELETE", "SUFFIX", d.suffix, err)
+		}
 	}
 
 	return nil
@@ -80,7 +80,7 @@ var _ = Describe("Delete", func() {
 		Expect(err).To(HaveOccurred())
 		Expect(args).To(BeNil())
 		Expect(sql).To(BeEmpty())
-		Expect(err.Error()).To(Equal("forced error"))
+		Expect(err).To(MatchError("sqlf: DELETE WHERE #1: forced error"))
 	})
 
 	It("should generate a DELETE with suffix", func() {
@@ -91,6 +91,14 @@ var _ = Describe("Delete", func() {
 		Expect(sql).To(Equal("DELETE FROM users SUFFIX"))
 	})
 
+	It("should generate a DELETE with a suffix with args", func() {
+		d := new(sqlf.DeleteStatement)
+		sql, args, err := d.Placeholder(sqlf.DollarPlaceholder).From("users").Where("id = ?", 1).Suffix("RETURNING id, ? AS deleted_at", "now").ToSQL()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(args).To(Equal([]interface{}{1, "now"}))
+		Expect(sql).To(Equal("DELETE FROM users WHERE id = $1 RETURNING id, $2 AS deleted_at"))
+	})
+
 	It("should generate a DELETE with placeholders", func() {
 		d := new(sqlf.DeleteStatement)
 		sql, args, err := d.Placeholder(sqlf.DollarPlaceholder).From("users").Where("id = ?", 1).ToSQL()
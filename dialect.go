@@ -0,0 +1,192 @@
+package sqlf
+
+import (
+	"errors"
+	"fmt"
+)
+
+// PaginationStyle defines how a dialect renders the LIMIT and OFFSET of a select.
+type PaginationStyle int
+
+const (
+	// PaginationLimitOffset renders `LIMIT ? OFFSET ?` (Postgres, MySQL and SQLite).
+	PaginationLimitOffset PaginationStyle = iota
+	// PaginationOffsetFetch renders `OFFSET ? ROWS FETCH NEXT ? ROWS ONLY` (SQL Server and the SQL standard).
+	PaginationOffsetFetch
+)
+
+// UpsertStyle defines how a dialect renders the conflict clause of an insert (`Insert.OnConflict`).
+type UpsertStyle int
+
+const (
+	// UpsertOnConflict renders `ON CONFLICT (...) DO ...` (Postgres and SQLite).
+	UpsertOnConflict UpsertStyle = iota
+	// UpsertOnDuplicateKey renders `ON DUPLICATE KEY UPDATE ...` (MySQL).
+	UpsertOnDuplicateKey
+	// UpsertNone means the dialect has no upsert syntax for inserts.
+	UpsertNone
+)
+
+// Feature is a SQL feature that is not supported by every database technology.
+type Feature string
+
+const (
+	// FeatureReturning is the RETURNING clause of inserts and updates.
+	FeatureReturning Feature = "RETURNING"
+	// FeatureILike is the case insensitive ILIKE operator.
+	FeatureILike Feature = "ILIKE"
+	// FeatureArrays are the array predicates (`= ANY(?)`, `<> ALL(?)`, `@>`, `<@` and `&&`).
+	FeatureArrays Feature = "array predicates"
+	// FeatureAggregateFilter is the FILTER (WHERE ...) clause of aggregates.
+	FeatureAggregateFilter Feature = "FILTER (WHERE ...) on aggregates"
+	// FeatureUpsert is the conflict clause of inserts.
+	FeatureUpsert Feature = "upsert"
+	// FeatureUpsertDoNothing is the DO NOTHING action of the conflict clause.
+	FeatureUpsertDoNothing Feature = "upsert DO NOTHING"
+	// FeatureUpsertWhere is the WHERE condition of the update action of the conflict clause.
+	FeatureUpsertWhere Feature = "upsert DO UPDATE ... WHERE"
+	// FeaturePostgresJSON are the JSON operators of `PostgresJSON` (`->`, `->>`, `#>`, `#>>`, `@>`, `?`, `?|` and
+	// `?&`).
+	FeaturePostgresJSON Feature = "the Postgres JSON operators"
+	// FeatureMySQLJSON are the JSON functions of `MySQLJSON` (`JSON_EXTRACT`, `JSON_CONTAINS`, ...).
+	FeatureMySQLJSON Feature = "the MySQL JSON functions"
+)
+
+// Dialect bundles the differences between database technologies: placeholders, identifier quoting, pagination,
+// upsert syntax, RETURNING support and literal rendering.
+//
+// Use `Postgres`, `MySQL`, `SQLite` or `SQLServer` on the `Builder`, or on each statement, with `Dialect`.
+type Dialect interface {
+	IdentifierQuoter
+
+	// Name returns the name of the dialect. Ex: `postgres`.
+	Name() string
+
+	// Placeholder returns the placeholder format used when the statement does not define one.
+	Placeholder() PlaceholderFormatFactory
+
+	// Pagination returns how LIMIT and OFFSET are rendered.
+	Pagination() PaginationStyle
+
+	// Upsert returns how the conflict clause of inserts is rendered.
+	Upsert() UpsertStyle
+
+	// Supports reports whether the dialect supports the `feature`.
+	Supports(feature Feature) bool
+
+	// WriteLiteral writes `value` as a SQL literal (strings, numbers, booleans, bytes, time, nil and
+	// `driver.Valuer`s). It fails with `ErrInvalidLiteral` for the values without one (Ex: NaN).
+	WriteLiteral(sb SQLWriter, value interface{}) error
+}
+
+var (
+	// ErrUnsupportedFeature is the error matched, through `errors.Is`, by any `UnsupportedFeatureError`.
+	ErrUnsupportedFeature = errors.New("unsupported feature")
+
+	// ErrInvalidLiteral is returned by `Dialect.WriteLiteral` when the value has no SQL literal. Ex: NaN.
+	ErrInvalidLiteral = errors.New("invalid literal")
+)
+
+// UnsupportedFeatureError is returned when a statement uses a feature that its dialect does not support.
+type UnsupportedFeatureError struct {
+	Dialect string
+	Feature Feature
+}
+
+// Error implements the `error` interface.
+func (err *UnsupportedFeatureError) Error() string {
+	return fmt.Sprintf("sqlf: the %s dialect does not support %s", err.Dialect, err.Feature)
+}
+
+// Is makes `errors.Is(err, ErrUnsupportedFeature)` match.
+func (err *UnsupportedFeatureError) Is(target error) bool {
+	return target == ErrUnsupportedFeature
+}
+
+// dialectOf returns the dialect of the statement being rendered. It is nil when no dialect was defined.
+func dialectOf(sb SQLWriter) Dialect {
+	if ctx, ok := contextOf(sb); ok {
+		return ctx.dialect
+	}
+	return nil
+}
+
+// checkFeature returns an `UnsupportedFeatureError` if the dialect of the statement being rendered does not
+// support the `feature`. When no dialect is defined, everything is allowed.
+func checkFeature(sb SQLWriter, feature Feature) error {
+	dialect := dialectOf(sb)
+	if dialect == nil || dialect.Supports(feature) {
+		return nil
+	}
+	return &UnsupportedFeatureError{
+		Dialect: dialect.Name(),
+		Feature: feature,
+	}
+}
+
+// literal renders a value as a SQL literal.
+type literal struct {
+	value interface{}
+}
+
+// ToSQLFast generates the SQL and returns it, alongside its params.
+func (l *literal) ToSQLFast(sb SQLWriter, _ *[]interface{}) error {
+	dialect := dialectOf(sb)
+	if dialect == nil {
+		dialect = standardDialect
+	}
+	return dialect.WriteLiteral(sb, l.value)
+}
+
+// Literal renders `value` as a SQL literal, instead of binding it as an argument, using the dialect of the
+// statement. Ex: `Literal(true)` renders `TRUE` for Postgres and `1` for SQL Server.
+func Literal(value interface{}) FastSqlizer {
+	return &literal{
+		value: value,
+	}
+}
+
+// excluded renders a reference to the value that was proposed for insertion in an upsert.
+type excluded struct {
+	column string
+}
+
+// ToSQLFast generates the SQL and returns it, alongside its params.
+func (e *excluded) ToSQLFast(sb SQLWriter, _ *[]interface{}) error {
+	dialect := dialectOf(sb)
+	if dialect != nil && dialect.Upsert() == UpsertOnDuplicateKey {
+		sb.Write(sqlExcludedValues)
+		err := e.writeColumn(sb)
+		if err != nil {
+			return err
+		}
+		sb.Write(sqlBracketClose)
+		return nil
+	}
+	sb.Write(sqlExcludedTable)
+	return e.writeColumn(sb)
+}
+
+// writeColumn writes the column. If the statement quotes all identifiers, it is quoted by the quoter of the
+// statement (falling back to the one of the dialect). Otherwise, in strict mode, it must be a plain identifier.
+func (e *excluded) writeColumn(sb SQLWriter) error {
+	if ctx, ok := contextOf(sb); ok && ctx.quoteAll {
+		identifierQuoterOf(sb).QuoteIdentifier(sb, e.column)
+		return nil
+	}
+	err := checkName(sb, e.column)
+	if err != nil {
+		return err
+	}
+	sb.WriteString(e.column)
+	return nil
+}
+
+// Excluded references the value proposed for insertion of `column` in the update action of an upsert. It renders
+// `EXCLUDED.<column>` (Postgres and SQLite) or `VALUES(<column>)` (MySQL). The column is quoted when the statement
+// quotes all identifiers (check `QuoteIdentifiers`).
+func Excluded(column string) FastSqlizer {
+	return &excluded{
+		column: column,
+	}
+}
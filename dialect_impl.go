@@ -0,0 +1,218 @@
+package sqlf
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	sqlLiteralNull    = []byte("NULL")
+	sqlExcludedTable  = []byte("EXCLUDED.")
+	sqlExcludedValues = []byte("VALUES(")
+)
+
+// literalStyle defines how a dialect writes literals.
+type literalStyle struct {
+	boolTrue    string
+	boolFalse   string
+	escaper     *strings.Replacer
+	bytesPrefix string
+	bytesSuffix string
+	timeLayout  string
+	// stringPrefix precedes the quoted strings. Ex: `N`, so SQL Server keeps the non-ASCII text.
+	stringPrefix string
+}
+
+// dialect is the default implementation of the `Dialect` interface.
+type dialect struct {
+	IdentifierQuoter
+	name        string
+	placeholder PlaceholderFormatFactory
+	pagination  PaginationStyle
+	upsert      UpsertStyle
+	features    map[Feature]bool
+	literals    literalStyle
+	// syntax are the rules used to tell the SQL code apart from literals, quoted identifiers and comments.
+	syntax lexerSyntax
+}
+
+var (
+	// Postgres is the dialect of PostgreSQL.
+	Postgres Dialect = &dialect{
+		IdentifierQuoter: DoubleQuoteIdentifier,
+		name:             "postgres",
+		placeholder:      DollarPlaceholder,
+		pagination:       PaginationLimitOffset,
+		upsert:           UpsertOnConflict,
+		features: map[Feature]bool{
+			FeatureReturning:       true,
+			FeatureILike:           true,
+			FeatureArrays:          true,
+			FeatureAggregateFilter: true,
+			FeatureUpsert:          true,
+			FeatureUpsertDoNothing: true,
+			FeatureUpsertWhere:     true,
+			FeaturePostgresJSON:    true,
+		},
+		literals: literalStyle{
+			boolTrue:    "TRUE",
+			boolFalse:   "FALSE",
+			escaper:     stringLiteralEscaper,
+			bytesPrefix: `'\x`,
+			bytesSuffix: `'`,
+			timeLayout:  "2006-01-02 15:04:05.999999999Z07:00",
+		},
+	}
+
+	// MySQL is the dialect of MySQL (and MariaDB).
+	MySQL Dialect = &dialect{
+		IdentifierQuoter: BacktickIdentifier,
+		name:             "mysql",
+		placeholder:      QuestionPlaceholder,
+		pagination:       PaginationLimitOffset,
+		upsert:           UpsertOnDuplicateKey,
+		syntax:           syntaxBackslashes,
+		features: map[Feature]bool{
+			FeatureUpsert:    true,
+			FeatureMySQLJSON: true,
+		},
+		literals: literalStyle{
+			boolTrue:    "TRUE",
+			boolFalse:   "FALSE",
+			escaper:     strings.NewReplacer("'", "''", `\`, `\\`),
+			bytesPrefix: "X'",
+			bytesSuffix: "'",
+			timeLayout:  "2006-01-02 15:04:05.999999",
+		},
+	}
+
+	// SQLite is the dialect of SQLite.
+	SQLite Dialect = &dialect{
+		IdentifierQuoter: DoubleQuoteIdentifier,
+		name:             "sqlite",
+		placeholder:      QuestionPlaceholder,
+		pagination:       PaginationLimitOffset,
+		upsert:           UpsertOnConflict,
+		features: map[Feature]bool{
+			FeatureReturning:       true,
+			FeatureAggregateFilter: true,
+			FeatureUpsert:          true,
+			FeatureUpsertDoNothing: true,
+			FeatureUpsertWhere:     true,
+		},
+		literals: literalStyle{
+			boolTrue:    "1",
+			boolFalse:   "0",
+			escaper:     stringLiteralEscaper,
+			bytesPrefix: "X'",
+			bytesSuffix: "'",
+			timeLayout:  "2006-01-02 15:04:05.999999999Z07:00",
+		},
+	}
+
+	// SQLServer is the dialect of Microsoft SQL Server.
+	SQLServer Dialect = &dialect{
+		IdentifierQuoter: BracketIdentifier,
+		name:             "sqlserver",
+		placeholder:      AtPPlaceholder,
+		pagination:       PaginationOffsetFetch,
+		upsert:           UpsertNone,
+		syntax:           syntaxBrackets,
+		features:         map[Feature]bool{},
+		literals: literalStyle{
+			boolTrue:     "1",
+			boolFalse:    "0",
+			escaper:      stringLiteralEscaper,
+			stringPrefix: "N",
+			bytesPrefix:  "0x",
+			timeLayout:   "2006-01-02 15:04:05.9999999",
+		},
+	}
+
+	// standardDialect is used to render literals when the statement has no dialect.
+	standardDialect Dialect = &dialect{
+		IdentifierQuoter: DoubleQuoteIdentifier,
+		name:             "standard",
+		literals: literalStyle{
+			boolTrue:    "TRUE",
+			boolFalse:   "FALSE",
+			escaper:     stringLiteralEscaper,
+			bytesPrefix: "X'",
+			bytesSuffix: "'",
+			timeLayout:  "2006-01-02 15:04:05.999999999Z07:00",
+		},
+	}
+)
+
+// Name returns the name of the dialect.
+func (d *dialect) Name() string {
+	return d.name
+}
+
+// Placeholder returns the placeholder format used when the statement does not define one.
+func (d *dialect) Placeholder() PlaceholderFormatFactory {
+	return d.placeholder
+}
+
+// Pagination returns how LIMIT and OFFSET are rendered.
+func (d *dialect) Pagination() PaginationStyle {
+	return d.pagination
+}
+
+// Upsert returns how the conflict clause of inserts is rendered.
+func (d *dialect) Upsert() UpsertStyle {
+	return d.upsert
+}
+
+// Supports reports whether the dialect supports the `feature`.
+func (d *dialect) Supports(feature Feature) bool {
+	return d.features[feature]
+}
+
+// WriteLiteral writes `value` as a SQL literal. The `value` is first converted by the
+// `driver.DefaultParameterConverter`, so `driver.Valuer`s, pointers and named types are accepted.
+func (d *dialect) WriteLiteral(sb SQLWriter, value interface{}) error {
+	v, err := driver.DefaultParameterConverter.ConvertValue(value)
+	if err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case nil:
+		sb.Write(sqlLiteralNull)
+	case bool:
+		if v {
+			sb.WriteString(d.literals.boolTrue)
+		} else {
+			sb.WriteString(d.literals.boolFalse)
+		}
+	case int64:
+		sb.WriteString(strconv.FormatInt(v, 10))
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("%w: %v is not a finite number", ErrInvalidLiteral, v)
+		}
+		sb.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
+	case string:
+		d.writeString(sb, v)
+	case []byte:
+		sb.WriteString(d.literals.bytesPrefix)
+		sb.WriteString(hex.EncodeToString(v))
+		sb.WriteString(d.literals.bytesSuffix)
+	case time.Time:
+		d.writeString(sb, v.Format(d.literals.timeLayout))
+	}
+	return nil
+}
+
+// writeString writes `s` quoted, escaping it according to the dialect.
+func (d *dialect) writeString(sb SQLWriter, s string) {
+	sb.WriteString(d.literals.stringPrefix)
+	sb.Write(sqlStringLiteralQuote)
+	d.literals.escaper.WriteString(sb, s)
+	sb.Write(sqlStringLiteralQuote)
+}
@@ -0,0 +1,197 @@
+package sqlf_test
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/jamillosantos/sqlf"
+)
+
+var _ = Describe("Dialect", func() {
+	It("should use the placeholder and quoter of the dialect", func() {
+		sql, args, err := sqlf.NewBuilder().
+			Dialect(sqlf.Postgres).
+			Select().
+			Select(sqlf.Ident("name")).
+			From("users").
+			Where("id = ? AND active = ?", 1, true).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(Equal([]interface{}{1, true}))
+		Expect(sql).To(Equal(`SELECT "name" FROM users WHERE id = $1 AND active = $2`))
+	})
+
+	It("should prefer the placeholder defined on the statement", func() {
+		sql, _, err := new(sqlf.SelectStatement).
+			Dialect(sqlf.Postgres).
+			Placeholder(sqlf.QuestionPlaceholder).
+			From("users").
+			Where("id = ?", 1).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("SELECT * FROM users WHERE id = ?"))
+	})
+
+	It("should quote every identifier using the dialect", func() {
+		sql, _, err := sqlf.NewBuilder().
+			Dialect(sqlf.MySQL).
+			QuoteIdentifiers(sqlf.MySQL).
+			Update("order", "o").
+			Set(sqlf.Ident("status"), "paid").
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("UPDATE `order` AS `o` SET `status` = ?"))
+	})
+
+	It("should render the LIMIT and OFFSET", func() {
+		sql, args, err := new(sqlf.SelectStatement).
+			Dialect(sqlf.SQLite).
+			From("users").
+			Limit(10).
+			Offset(20).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(Equal([]interface{}{10, 20}))
+		Expect(sql).To(Equal("SELECT * FROM users LIMIT ? OFFSET ?"))
+	})
+
+	DescribeTable("SQL Server pagination",
+		func(build func(s sqlf.Select), expectedSQL string, expectedArgs []interface{}) {
+			s := new(sqlf.SelectStatement).Dialect(sqlf.SQLServer).From("users")
+			build(s)
+			sql, args, err := s.ToSQL()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(args).To(Equal(expectedArgs))
+			Expect(sql).To(Equal(expectedSQL))
+		},
+		Entry("limit and offset", func(s sqlf.Select) {
+			s.OrderBy("name").Limit(10).Offset(20)
+		}, "SELECT * FROM users ORDER BY name OFFSET @p1 ROWS FETCH NEXT @p2 ROWS ONLY", []interface{}{20, 10}),
+		Entry("limit only", func(s sqlf.Select) {
+			s.OrderBy("name").Limit(10)
+		}, "SELECT * FROM users ORDER BY name OFFSET 0 ROWS FETCH NEXT @p1 ROWS ONLY", []interface{}{10}),
+		Entry("offset only", func(s sqlf.Select) {
+			s.OrderBy("name").Offset(20)
+		}, "SELECT * FROM users ORDER BY name OFFSET @p1 ROWS", []interface{}{20}),
+		Entry("without order by", func(s sqlf.Select) {
+			s.Limit(10)
+		}, "SELECT * FROM users ORDER BY (SELECT NULL) OFFSET 0 ROWS FETCH NEXT @p1 ROWS ONLY", []interface{}{10}),
+		Entry("no pagination", func(s sqlf.Select) {
+			s.OrderBy("name")
+		}, "SELECT * FROM users ORDER BY name", []interface{}{}),
+	)
+
+	It("should fail using RETURNING on a dialect that does not support it", func() {
+		_, _, err := sqlf.NewBuilder().
+			Dialect(sqlf.MySQL).
+			Insert("users", "name").
+			Values("Name 1").
+			Returning("id").
+			ToSQL()
+		Expect(errors.Is(err, sqlf.ErrUnsupportedFeature)).To(BeTrue())
+		var featureErr *sqlf.UnsupportedFeatureError
+		Expect(errors.As(err, &featureErr)).To(BeTrue())
+		Expect(featureErr.Dialect).To(Equal("mysql"))
+		Expect(featureErr.Feature).To(Equal(sqlf.FeatureReturning))
+		Expect(err).To(MatchError("sqlf: INSERT RETURNING: the mysql dialect does not support RETURNING"))
+	})
+
+	It("should fail using a Postgres predicate inside a nested statement", func() {
+		_, _, err := new(sqlf.SelectStatement).
+			Dialect(sqlf.SQLite).
+			From("users").
+			WhereCriteria(sqlf.In("id", new(sqlf.SelectStatement).Select("user_id").From("tags").WhereCriteria(sqlf.EqAny("tag", []string{"a"})))).
+			ToSQL()
+		var featureErr *sqlf.UnsupportedFeatureError
+		Expect(errors.As(err, &featureErr)).To(BeTrue())
+		Expect(*featureErr).To(Equal(sqlf.UnsupportedFeatureError{Dialect: "sqlite", Feature: sqlf.FeatureArrays}))
+	})
+
+	DescribeTable("unsupported features",
+		func(dialect sqlf.Dialect, criteria sqlf.FastSqlizer, feature sqlf.Feature) {
+			_, _, err := new(sqlf.SelectStatement).Dialect(dialect).From("users").WhereCriteria(criteria).ToSQL()
+			var featureErr *sqlf.UnsupportedFeatureError
+			Expect(errors.As(err, &featureErr)).To(BeTrue())
+			Expect(*featureErr).To(Equal(sqlf.UnsupportedFeatureError{Dialect: dialect.Name(), Feature: feature}))
+		},
+		Entry("ILIKE on MySQL", sqlf.MySQL, sqlf.ILike("name", "%a%"), sqlf.FeatureILike),
+		Entry("arrays on SQL Server", sqlf.SQLServer, sqlf.ArrayContains("tags", []string{"a"}), sqlf.FeatureArrays),
+		Entry("FILTER on MySQL", sqlf.MySQL, sqlf.Gt(sqlf.Count().Filter(sqlf.Eq("active", true)), 1), sqlf.FeatureAggregateFilter),
+		Entry("Postgres JSON on MySQL", sqlf.MySQL, sqlf.PostgresJSON.HasKey("data", "email"), sqlf.FeaturePostgresJSON),
+		Entry("MySQL JSON on Postgres", sqlf.Postgres, sqlf.MySQLJSON.HasKey("data", "email"), sqlf.FeatureMySQLJSON),
+		Entry("Postgres JSON on SQLite", sqlf.SQLite, sqlf.PostgresJSON.GetText("data", "name"), sqlf.FeaturePostgresJSON),
+	)
+
+	It("should allow every feature without a dialect", func() {
+		sql, _, err := new(sqlf.SelectStatement).From("users").WhereCriteria(sqlf.ILike("name", "%a%")).ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("SELECT * FROM users WHERE name ILIKE ?"))
+	})
+
+	Describe("Literal", func() {
+		date := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+
+		DescribeTable("rendering",
+			func(dialect sqlf.Dialect, value interface{}, expected string) {
+				sb := new(strings.Builder)
+				Expect(dialect.WriteLiteral(sb, value)).To(Succeed())
+				Expect(sb.String()).To(Equal(expected))
+			},
+			Entry("postgres nil", sqlf.Postgres, nil, "NULL"),
+			Entry("postgres true", sqlf.Postgres, true, "TRUE"),
+			Entry("sqlserver true", sqlf.SQLServer, true, "1"),
+			Entry("sqlite false", sqlf.SQLite, false, "0"),
+			Entry("int", sqlf.Postgres, 42, "42"),
+			Entry("float", sqlf.MySQL, 1.5, "1.5"),
+			Entry("postgres string", sqlf.Postgres, `it's a \ test`, `'it''s a \ test'`),
+			Entry("mysql string", sqlf.MySQL, `it's a \ test`, `'it''s a \\ test'`),
+			Entry("postgres bytes", sqlf.Postgres, []byte{0xde, 0xad}, `'\xdead'`),
+			Entry("mysql bytes", sqlf.MySQL, []byte{0xde, 0xad}, "X'dead'"),
+			Entry("sqlserver bytes", sqlf.SQLServer, []byte{0xde, 0xad}, "0xdead"),
+			Entry("sqlserver string", sqlf.SQLServer, "São Paulo's", "N'São Paulo''s'"),
+			Entry("sqlserver time", sqlf.SQLServer, date, "N'2021-03-04 05:06:07'"),
+			Entry("postgres time", sqlf.Postgres, date, "'2021-03-04 05:06:07Z'"),
+			Entry("mysql time", sqlf.MySQL, date, "'2021-03-04 05:06:07'"),
+			Entry("valuer", sqlf.Postgres, sqlf.Array([]int{1, 2}), "'{1,2}'"),
+		)
+
+		It("should fail rendering an unsupported value", func() {
+			sb := new(strings.Builder)
+			Expect(sqlf.Postgres.WriteLiteral(sb, struct{}{})).ToNot(Succeed())
+		})
+
+		It("should fail rendering a float that is not finite", func() {
+			for _, value := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+				sb := new(strings.Builder)
+				err := sqlf.Postgres.WriteLiteral(sb, value)
+				Expect(errors.Is(err, sqlf.ErrInvalidLiteral)).To(BeTrue())
+				Expect(sb.String()).To(BeEmpty())
+			}
+			_, err := new(sqlf.SelectStatement).Dialect(sqlf.Postgres).From("items").Where("price = ?", math.Inf(1)).ToInterpolatedSQL()
+			Expect(err).To(MatchError("sqlf: cannot interpolate the arg #1 (float64): invalid literal: +Inf is not a finite number"))
+		})
+
+		It("should render using the dialect of the statement", func() {
+			sql, args, err := new(sqlf.SelectStatement).
+				Dialect(sqlf.SQLServer).
+				From("users").
+				WhereCriteria(sqlf.Eq("active", sqlf.Literal(true))).
+				ToSQL()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(args).To(BeEmpty())
+			Expect(sql).To(Equal("SELECT * FROM users WHERE active = 1"))
+		})
+
+		It("should render with the standard style without a dialect", func() {
+			sb, args := new(strings.Builder), make([]interface{}, 0)
+			Expect(sqlf.Literal(false).ToSQLFast(sb, &args)).To(Succeed())
+			Expect(sb.String()).To(Equal("FALSE"))
+		})
+	})
+})
@@ -0,0 +1,149 @@
+package sqlf
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// ErrNoExecutor is returned when a statement is executed without an `Executor`. Check `Select.RunWith`.
+	ErrNoExecutor = errors.New("the statement has no executor, define one with RunWith")
+)
+
+// Executor runs the SQL generated by the statements. It is implemented by `*sql.DB`, `*sql.Tx` and `*sql.Conn`.
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Runner is implemented by the statements that can be executed by their `Executor` (check `Select.RunWith`).
+type Runner interface {
+	// ExecContext executes the statement without returning any rows.
+	ExecContext(ctx context.Context) (sql.Result, error)
+
+	// QueryContext executes the statement returning its rows.
+	QueryContext(ctx context.Context) (*sql.Rows, error)
+
+	// QueryRowContext executes the statement that is expected to return at most one row. Errors, including the
+	// ones from the generation of the SQL, are deferred until `Row.Scan` is called.
+	QueryRowContext(ctx context.Context) *Row
+}
+
+// Row is the result of `Runner.QueryRowContext`. It works as a `*sql.Row` that may also hold the error that
+// happened before the query was executed.
+type Row struct {
+	row *sql.Row
+	err error
+}
+
+// Scan copies the columns of the row into the values pointed at by `dest`. It returns the error that prevented
+// the query from being executed, if any. Check `sql.Row.Scan`.
+func (r *Row) Scan(dest ...interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	return r.row.Scan(dest...)
+}
+
+// Err returns the error that prevented the query from being executed, if any. Otherwise, it returns the error of
+// the query (without calling `Scan`). Check `sql.Row.Err`.
+func (r *Row) Err() error {
+	if r.err != nil {
+		return r.err
+	}
+	return r.row.Err()
+}
+
+// execContext generates the SQL of the `statement` and executes it with `db`.
+func execContext(ctx context.Context, db Executor, statement Sqlizer) (sql.Result, error) {
+	if db == nil {
+		return nil, ErrNoExecutor
+	}
+	query, args, err := statement.ToSQL()
+	if err != nil {
+		return nil, err
+	}
+	return db.ExecContext(ctx, query, args...)
+}
+
+// queryContext generates the SQL of the `statement` and queries its rows with `db`.
+func queryContext(ctx context.Context, db Executor, statement Sqlizer) (*sql.Rows, error) {
+	if db == nil {
+		return nil, ErrNoExecutor
+	}
+	query, args, err := statement.ToSQL()
+	if err != nil {
+		return nil, err
+	}
+	return db.QueryContext(ctx, query, args...)
+}
+
+// queryRowContext generates the SQL of the `statement` and queries a single row with `db`.
+func queryRowContext(ctx context.Context, db Executor, statement Sqlizer) *Row {
+	if db == nil {
+		return &Row{err: ErrNoExecutor}
+	}
+	query, args, err := statement.ToSQL()
+	if err != nil {
+		return &Row{err: err}
+	}
+	return &Row{row: db.QueryRowContext(ctx, query, args...)}
+}
+
+// scanReturning scans the `rows` of a statement into the `targets`, in order, closing them. When `exact` is set,
+// there must be one row per target, otherwise it fails with `ErrReturningMismatch` and the targets are left
+// untouched. Otherwise, the rows beyond the targets are only counted. The returned `sql.Result` reports the rows
+// returned as affected. Check `ReturningGenerated`.
+func scanReturning(rows *sql.Rows, targets []reflect.Value, exact bool) (sql.Result, error) {
+	defer rows.Close()
+
+	t := targets[0].Type()
+	indexes, err := columnIndexes(rows, t)
+	if err != nil {
+		return nil, err
+	}
+	// The rows are scanned into copies, so a failure does not leave the targets half scanned.
+	scanned := make([]reflect.Value, 0, len(targets))
+	var affected int64
+	buf := make([]interface{}, len(indexes))
+	for rows.Next() {
+		if affected < int64(len(targets)) {
+			v := reflect.New(t).Elem()
+			v.Set(targets[affected])
+			err = scanStruct(rows, v, indexes, buf)
+			if err != nil {
+				return nil, err
+			}
+			scanned = append(scanned, v)
+		}
+		affected++
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+	if exact && affected != int64(len(targets)) {
+		return nil, fmt.Errorf("sqlf: %w: got %d rows for %d structs", ErrReturningMismatch, affected, len(targets))
+	}
+	for i, v := range scanned {
+		targets[i].Set(v)
+	}
+	return returningResult(affected), rows.Close()
+}
+
+// returningResult is the `sql.Result` of `scanReturning`: the number of rows returned.
+type returningResult int64
+
+// LastInsertId returns `ErrNoLastInsertID`, as the generated columns are scanned into the structs.
+func (r returningResult) LastInsertId() (int64, error) {
+	return 0, ErrNoLastInsertID
+}
+
+// RowsAffected returns the number of rows returned.
+func (r returningResult) RowsAffected() (int64, error) {
+	return int64(r), nil
+}
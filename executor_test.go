@@ -0,0 +1,137 @@
+package sqlf_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/jamillosantos/sqlf"
+	"github.com/jamillosantos/sqlf/testingutils"
+)
+
+var _ = Describe("Executor", func() {
+	var (
+		ctx context.Context
+		fd  *testingutils.FakeDriver
+		db  *sql.DB
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fd = &testingutils.FakeDriver{
+			Columns:      []string{"id", "name"},
+			Rows:         [][]driver.Value{{int64(1), "john"}, {int64(2), "jane"}},
+			RowsAffected: 3,
+		}
+		db = fd.DB()
+	})
+
+	AfterEach(func() {
+		Expect(db.Close()).To(Succeed())
+	})
+
+	It("should query the rows of a select", func() {
+		rows, err := new(sqlf.SelectStatement).
+			Placeholder(sqlf.DollarPlaceholder).
+			Select("id", "name").
+			From("users").
+			Where("active = ?", true).
+			RunWith(db).
+			QueryContext(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		defer rows.Close()
+
+		names := make([]string, 0)
+		for rows.Next() {
+			var (
+				id   int64
+				name string
+			)
+			Expect(rows.Scan(&id, &name)).To(Succeed())
+			names = append(names, name)
+		}
+		Expect(rows.Err()).ToNot(HaveOccurred())
+		Expect(names).To(Equal([]string{"john", "jane"}))
+		Expect(fd.LastQuery()).To(Equal(testingutils.FakeQuery{
+			SQL:  "SELECT id, name FROM users WHERE active = $1",
+			Args: []interface{}{true},
+		}))
+	})
+
+	It("should query a single row", func() {
+		var name string
+		err := new(sqlf.SelectStatement).Select("name").From("users").Where("id = ?", 1).RunWith(db).QueryRowContext(ctx).Scan(new(int64), &name)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(name).To(Equal("john"))
+		Expect(fd.LastQuery().SQL).To(Equal("SELECT name FROM users WHERE id = ?"))
+	})
+
+	It("should execute inserts, updates and deletes", func() {
+		b := sqlf.NewBuilder().RunWith(db)
+
+		result, err := b.Insert("users", "name").Values("john").ExecContext(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RowsAffected()).To(Equal(int64(3)))
+
+		_, err = b.Update("users").Set("name", "jane").Where("id = ?", 1).ExecContext(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = b.Delete("users").Where("id = ?", 2).ExecContext(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fd.Queries()).To(Equal([]testingutils.FakeQuery{
+			{SQL: "INSERT INTO users (name) VALUES (?)", Args: []interface{}{"john"}},
+			{SQL: "UPDATE users SET name = ? WHERE id = ?", Args: []interface{}{"jane", int64(1)}},
+			{SQL: "DELETE FROM users WHERE id = ?", Args: []interface{}{int64(2)}},
+		}))
+	})
+
+	It("should run within a transaction", func() {
+		tx, err := db.BeginTx(ctx, nil)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = sqlf.NewBuilder().RunWith(tx).Delete("users").ExecContext(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tx.Commit()).To(Succeed())
+		Expect(fd.LastQuery().SQL).To(Equal("DELETE FROM users"))
+	})
+
+	It("should return the rows of the returning clause", func() {
+		var id int64
+		err := sqlf.NewBuilder().RunWith(db).Insert("users", "name").Values("john").Returning("id").QueryRowContext(ctx).Scan(&id, new(string))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(id).To(Equal(int64(1)))
+	})
+
+	It("should fail without an executor", func() {
+		s := new(sqlf.SelectStatement).From("users")
+		_, err := s.ExecContext(ctx)
+		Expect(err).To(MatchError(sqlf.ErrNoExecutor))
+		_, err = s.QueryContext(ctx)
+		Expect(err).To(MatchError(sqlf.ErrNoExecutor))
+		Expect(s.QueryRowContext(ctx).Scan()).To(MatchError(sqlf.ErrNoExecutor))
+		Expect(fd.Queries()).To(BeEmpty())
+	})
+
+	It("should not execute statements that fail to render", func() {
+		insert := new(sqlf.InsertStatement).Into("users", "id", "name").Values(1).RunWith(db)
+		_, err := insert.ExecContext(ctx)
+		Expect(errors.Is(err, sqlf.ErrMismatchFieldsAndValuesCount)).To(BeTrue())
+
+		row := insert.QueryRowContext(ctx)
+		Expect(errors.Is(row.Err(), sqlf.ErrMismatchFieldsAndValuesCount)).To(BeTrue())
+		Expect(errors.Is(row.Scan(), sqlf.ErrMismatchFieldsAndValuesCount)).To(BeTrue())
+		Expect(fd.Queries()).To(BeEmpty())
+	})
+
+	It("should return the errors of the database", func() {
+		forcedErr := errors.New("forced error")
+		fd.Err = forcedErr
+		_, err := new(sqlf.DeleteStatement).From("users").RunWith(db).ExecContext(ctx)
+		Expect(err).To(MatchError(forcedErr))
+		Expect(new(sqlf.SelectStatement).From("users").RunWith(db).QueryRowContext(ctx).Err()).To(MatchError(forcedErr))
+	})
+})
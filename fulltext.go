@@ -0,0 +1,233 @@
+package sqlf
+
+import "strings"
+
+var (
+	sqlTSVector           = []byte("to_tsvector(")
+	sqlTSWebSearchQuery   = []byte("websearch_to_tsquery(")
+	sqlTSMatch            = []byte(" @@ ")
+	sqlTSRank             = []byte("ts_rank(")
+	sqlTSConcat           = []byte(" || ' ' || ")
+	sqlTSCoalesce         = []byte("coalesce(")
+	sqlTSCoalesceEmpty    = []byte(", '')")
+	sqlMySQLMatch         = []byte("MATCH(")
+	sqlMySQLAgainst       = []byte(") AGAINST(")
+	sqlMySQLBooleanMode   = []byte(" IN BOOLEAN MODE)")
+	sqlSQLiteMatch        = []byte(" MATCH ")
+	sqlSQLiteBM25         = []byte("bm25(")
+	sqlStringLiteralQuote = []byte("'")
+	stringLiteralEscaper  = strings.NewReplacer("'", "''")
+)
+
+// tsVector renders the Postgres `to_tsvector` function.
+type tsVector struct {
+	config  string
+	columns []interface{}
+}
+
+// ToSQLFast generates the SQL and returns it, alongside its params.
+func (v *tsVector) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
+	sb.Write(sqlTSVector)
+	if v.config != "" {
+		writeStringLiteral(sb, v.config)
+		sb.Write(sqlComma)
+	}
+	if len(v.columns) == 1 {
+		err := RenderInterfaceAsSQL(sb, args, v.columns[0])
+		if err != nil {
+			return err
+		}
+	} else {
+		for idx, column := range v.columns {
+			if idx > 0 {
+				sb.Write(sqlTSConcat)
+			}
+			sb.Write(sqlTSCoalesce)
+			err := RenderInterfaceAsSQL(sb, args, column)
+			if err != nil {
+				return err
+			}
+			sb.Write(sqlTSCoalesceEmpty)
+		}
+	}
+	sb.Write(sqlBracketClose)
+	return nil
+}
+
+// TSVector renders `to_tsvector('<config>', <columns>)`. When multiple columns are given, they are concatenated
+// (with `coalesce`, so NULLs do not discard the whole document). An empty `config` uses the database default.
+func TSVector(config string, columns ...interface{}) FastSqlizer {
+	return &tsVector{
+		config:  config,
+		columns: columns,
+	}
+}
+
+// tsQuery renders the Postgres `websearch_to_tsquery` function.
+type tsQuery struct {
+	config string
+	query  interface{}
+}
+
+// ToSQLFast generates the SQL and returns it, alongside its params.
+func (q *tsQuery) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
+	sb.Write(sqlTSWebSearchQuery)
+	if q.config != "" {
+		writeStringLiteral(sb, q.config)
+		sb.Write(sqlComma)
+	}
+	err := renderPredicateValue(sb, args, q.query)
+	if err != nil {
+		return err
+	}
+	sb.Write(sqlBracketClose)
+	return nil
+}
+
+// WebSearchToTSQuery renders `websearch_to_tsquery('<config>', ?)` binding `query` as an argument. An empty
+// `config` uses the database default.
+func WebSearchToTSQuery(config string, query interface{}) FastSqlizer {
+	return &tsQuery{
+		config: config,
+		query:  query,
+	}
+}
+
+// tsFunction renders Postgres text search operations between a vector and a query.
+type tsFunction struct {
+	prefix    []byte
+	separator []byte
+	suffix    []byte
+	vector    interface{}
+	query     interface{}
+}
+
+// ToSQLFast generates the SQL and returns it, alongside its params.
+func (f *tsFunction) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
+	sb.Write(f.prefix)
+	err := RenderInterfaceAsSQL(sb, args, f.vector)
+	if err != nil {
+		return err
+	}
+	sb.Write(f.separator)
+	query, ok := f.query.(FastSqlizer)
+	if !ok {
+		query = WebSearchToTSQuery("", f.query)
+	}
+	err = query.ToSQLFast(sb, args)
+	if err != nil {
+		return err
+	}
+	sb.Write(f.suffix)
+	return nil
+}
+
+// TSMatch renders `<vector> @@ <query>`.
+//
+// `vector` is rendered as SQL, so it can be a `tsvector` column or a `TSVector`. `query` can be a
+// `WebSearchToTSQuery` (or any other `FastSqlizer`), otherwise it is bound as `websearch_to_tsquery(?)`.
+//
+// Example:
+//
+//	vector := sqlf.TSVector("english", "title", "body")
+//	query := sqlf.WebSearchToTSQuery("english", "quick fox")
+//	s.WhereCriteria(sqlf.TSMatch(vector, query)).OrderByX(func(o sqlf.OrderBy) {
+//	    o.Desc(sqlf.TSRank(vector, query))
+//	})
+func TSMatch(vector interface{}, query interface{}) FastSqlizer {
+	return &tsFunction{
+		separator: sqlTSMatch,
+		vector:    vector,
+		query:     query,
+	}
+}
+
+// TSRank renders `ts_rank(<vector>, <query>)`, the ranking expression used to sort the results of a `TSMatch`.
+// `vector` and `query` follow the same rules of `TSMatch`.
+func TSRank(vector interface{}, query interface{}) FastSqlizer {
+	return &tsFunction{
+		prefix:    sqlTSRank,
+		separator: sqlComma,
+		suffix:    sqlBracketClose,
+		vector:    vector,
+		query:     query,
+	}
+}
+
+// matchAgainst renders the MySQL `MATCH ... AGAINST` function.
+type matchAgainst struct {
+	columns []interface{}
+	query   interface{}
+}
+
+// ToSQLFast generates the SQL and returns it, alongside its params.
+func (m *matchAgainst) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
+	sb.Write(sqlMySQLMatch)
+	for idx, column := range m.columns {
+		if idx > 0 {
+			sb.Write(sqlComma)
+		}
+		err := RenderInterfaceAsSQL(sb, args, column)
+		if err != nil {
+			return err
+		}
+	}
+	sb.Write(sqlMySQLAgainst)
+	err := renderPredicateValue(sb, args, m.query)
+	if err != nil {
+		return err
+	}
+	sb.Write(sqlMySQLBooleanMode)
+	return nil
+}
+
+// MatchAgainst renders the MySQL `MATCH(<columns>) AGAINST(? IN BOOLEAN MODE)`.
+//
+// MySQL returns the relevance of the match for this same expression. So, it can also be used in the
+// ORDER BY clause for ranking.
+func MatchAgainst(query interface{}, columns ...interface{}) FastSqlizer {
+	return &matchAgainst{
+		columns: columns,
+		query:   query,
+	}
+}
+
+// FTSMatch renders the SQLite FTS `<table> MATCH ?`.
+func FTSMatch(table interface{}, query interface{}) FastSqlizer {
+	return &comparison{
+		column:   table,
+		operator: sqlSQLiteMatch,
+		value:    query,
+	}
+}
+
+// ftsRank renders the SQLite FTS5 `bm25` function.
+type ftsRank struct {
+	table interface{}
+}
+
+// ToSQLFast generates the SQL and returns it, alongside its params.
+func (r *ftsRank) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
+	sb.Write(sqlSQLiteBM25)
+	err := RenderInterfaceAsSQL(sb, args, r.table)
+	if err != nil {
+		return err
+	}
+	sb.Write(sqlBracketClose)
+	return nil
+}
+
+// FTSRank renders the SQLite FTS5 `bm25(<table>)` ranking function. Better matches have lower values, so it should
+// be sorted in ascending order.
+func FTSRank(table interface{}) FastSqlizer {
+	return &ftsRank{
+		table: table,
+	}
+}
+
+// writeStringLiteral writes `s` as a SQL string literal, doubling the single quotes.
+func writeStringLiteral(sb SQLWriter, s string) {
+	sb.Write(sqlStringLiteralQuote)
+	stringLiteralEscaper.WriteString(sb, s)
+	sb.Write(sqlStringLiteralQuote)
+}
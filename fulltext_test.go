@@ -0,0 +1,77 @@
+package sqlf_test
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/jamillosantos/sqlf"
+	"github.com/jamillosantos/sqlf/testingutils"
+)
+
+var _ = Describe("Full text search", func() {
+	DescribeTable("expressions",
+		func(sqlizer sqlf.FastSqlizer, expectedSQL string, expectedArgs []interface{}) {
+			sb, args := new(strings.Builder), make([]interface{}, 0)
+			err := sqlizer.ToSQLFast(sb, &args)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sb.String()).To(Equal(expectedSQL))
+			Expect(args).To(Equal(expectedArgs))
+		},
+		Entry("TSVector", sqlf.TSVector("english", "title"), "to_tsvector('english', title)", []interface{}{}),
+		Entry("TSVector with multiple columns", sqlf.TSVector("english", "title", "body"), "to_tsvector('english', coalesce(title, '') || ' ' || coalesce(body, ''))", []interface{}{}),
+		Entry("TSVector without config", sqlf.TSVector("", "title"), "to_tsvector(title)", []interface{}{}),
+		Entry("TSVector escaping config", sqlf.TSVector("it's", "title"), "to_tsvector('it''s', title)", []interface{}{}),
+		Entry("WebSearchToTSQuery", sqlf.WebSearchToTSQuery("english", "quick fox"), "websearch_to_tsquery('english', ?)", []interface{}{"quick fox"}),
+		Entry("TSMatch", sqlf.TSMatch(sqlf.TSVector("english", "title"), sqlf.WebSearchToTSQuery("english", "fox")), "to_tsvector('english', title) @@ websearch_to_tsquery('english', ?)", []interface{}{"fox"}),
+		Entry("TSMatch with a tsvector column and a plain query", sqlf.TSMatch("search_vector", "fox"), "search_vector @@ websearch_to_tsquery(?)", []interface{}{"fox"}),
+		Entry("TSRank", sqlf.TSRank("search_vector", "fox"), "ts_rank(search_vector, websearch_to_tsquery(?))", []interface{}{"fox"}),
+		Entry("MatchAgainst", sqlf.MatchAgainst("+fox -dog", "title", "body"), "MATCH(title, body) AGAINST(? IN BOOLEAN MODE)", []interface{}{"+fox -dog"}),
+		Entry("FTSMatch", sqlf.FTSMatch("documents", "fox"), "documents MATCH ?", []interface{}{"fox"}),
+		Entry("FTSRank", sqlf.FTSRank("documents"), "bm25(documents)", []interface{}{}),
+	)
+
+	It("should fail generating an errored column", func() {
+		sb, args := new(strings.Builder), make([]interface{}, 0)
+		err := sqlf.TSVector("english", "title", &testingutils.MockerSqlizer{
+			Err: errors.New("forced error"),
+		}).ToSQLFast(sb, &args)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("forced error"))
+	})
+
+	It("should generate a Postgres search with ranking in the right argument order", func() {
+		vector := sqlf.TSVector("english", "title", "body")
+		query := sqlf.WebSearchToTSQuery("english", "quick fox")
+		sql, args, err := new(sqlf.SelectStatement).
+			Placeholder(sqlf.DollarPlaceholder).
+			Select("id", sqlf.TSRank(vector, query)).
+			From("posts").
+			Where("account_id = ?", 7).
+			WhereCriteria(sqlf.TSMatch(vector, query)).
+			OrderByX(func(orderBy sqlf.OrderBy) {
+				orderBy.Desc(sqlf.TSRank(vector, query))
+			}).
+			Limit(10).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(Equal([]interface{}{"quick fox", 7, "quick fox", "quick fox", 10}))
+		Expect(sql).To(Equal("SELECT id, ts_rank(to_tsvector('english', coalesce(title, '') || ' ' || coalesce(body, '')), websearch_to_tsquery('english', $1)) FROM posts WHERE account_id = $2 AND to_tsvector('english', coalesce(title, '') || ' ' || coalesce(body, '')) @@ websearch_to_tsquery('english', $3) ORDER BY ts_rank(to_tsvector('english', coalesce(title, '') || ' ' || coalesce(body, '')), websearch_to_tsquery('english', $4)) DESC LIMIT $5"))
+	})
+
+	It("should generate a MySQL search with ranking", func() {
+		sql, args, err := new(sqlf.SelectStatement).
+			From("posts").
+			WhereCriteria(sqlf.MatchAgainst("fox", "title", "body")).
+			OrderByX(func(orderBy sqlf.OrderBy) {
+				orderBy.Desc(sqlf.MatchAgainst("fox", "title", "body"))
+			}).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(Equal([]interface{}{"fox", "fox"}))
+		Expect(sql).To(Equal("SELECT * FROM posts WHERE MATCH(title, body) AGAINST(? IN BOOLEAN MODE) ORDER BY MATCH(title, body) AGAINST(? IN BOOLEAN MODE) DESC"))
+	})
+})
@@ -0,0 +1,228 @@
+package sqlf
+
+var (
+	sqlFunctionAllArgs     = []byte("*")
+	sqlFunctionWithinGroup = []byte(" WITHIN GROUP (ORDER BY ")
+	sqlFunctionFilter      = []byte(" FILTER (WHERE ")
+)
+
+// Function represents a SQL function call, including aggregates.
+type Function interface {
+	FastSqlizer
+
+	// Distinct adds the DISTINCT modifier to the arguments. Ex: `COUNT(DISTINCT x)`.
+	Distinct() Function
+
+	// OrderBy adds an ORDER BY, on an ascending order, to the arguments. Ex: `string_agg(x, ',' ORDER BY y)`.
+	OrderBy(fields ...interface{}) Function
+
+	// OrderByX adds an ORDER BY to the arguments and returns the OrderBy itself for further configuration.
+	OrderByX(callback func(orderBy OrderBy)) Function
+
+	// WithinGroup adds the WITHIN GROUP (ORDER BY ...) clause, on an ascending order, used by ordered-set
+	// aggregates. Ex: `percentile_cont(?) WITHIN GROUP (ORDER BY price)`.
+	WithinGroup(fields ...interface{}) Function
+
+	// WithinGroupX adds the WITHIN GROUP (ORDER BY ...) clause and returns the OrderBy itself for further
+	// configuration.
+	WithinGroupX(callback func(orderBy OrderBy)) Function
+
+	// Filter adds the FILTER (WHERE ...) clause to an aggregate. The criteria are joined by the AND operator.
+	Filter(criteria ...FastSqlizer) Function
+}
+
+// FunctionCall is the default implementation of the `Function` interface.
+type FunctionCall struct {
+	name        string
+	distinct    bool
+	args        []interface{}
+	orderBy     *OrderByClause
+	withinGroup *OrderByClause
+	filter      []FastSqlizer
+}
+
+// Func creates a call to the function `name`.
+//
+// The `args` are rendered as SQL, so strings are columns (or any SQL) and `FastSqlizer`s are rendered inline. Use
+// `Arg` to bind a value as an argument.
+func Func(name string, args ...interface{}) Function {
+	return &FunctionCall{
+		name: name,
+		args: args,
+	}
+}
+
+// Count creates a `COUNT(<args>)`. If no `args` are given, it renders `COUNT(*)`.
+func Count(args ...interface{}) Function {
+	if len(args) == 0 {
+		return Func("COUNT", sqlFunctionAllArgs)
+	}
+	return Func("COUNT", args...)
+}
+
+// CountDistinct creates a `COUNT(DISTINCT <arg>)`.
+func CountDistinct(arg interface{}) Function {
+	return Func("COUNT", arg).Distinct()
+}
+
+// Sum creates a `SUM(<arg>)`.
+func Sum(arg interface{}) Function {
+	return Func("SUM", arg)
+}
+
+// Avg creates a `AVG(<arg>)`.
+func Avg(arg interface{}) Function {
+	return Func("AVG", arg)
+}
+
+// Min creates a `MIN(<arg>)`.
+func Min(arg interface{}) Function {
+	return Func("MIN", arg)
+}
+
+// Max creates a `MAX(<arg>)`.
+func Max(arg interface{}) Function {
+	return Func("MAX", arg)
+}
+
+// StringAgg creates a Postgres `string_agg(<arg>, '<separator>')`.
+func StringAgg(arg interface{}, separator string) Function {
+	return Func("string_agg", arg, &stringLiteral{value: separator})
+}
+
+// PercentileCont creates a `percentile_cont(?)`, binding `fraction` as an argument. It should be followed by a
+// `WithinGroup`.
+func PercentileCont(fraction interface{}) Function {
+	return Func("percentile_cont", Arg(fraction))
+}
+
+// Distinct adds the DISTINCT modifier to the arguments.
+func (f *FunctionCall) Distinct() Function {
+	f.distinct = true
+	return f
+}
+
+// OrderBy adds an ORDER BY, on an ascending order, to the arguments.
+func (f *FunctionCall) OrderBy(fields ...interface{}) Function {
+	if f.orderBy == nil {
+		f.orderBy = &OrderByClause{}
+	}
+	f.orderBy.Asc(fields...)
+	return f
+}
+
+// OrderByX adds an ORDER BY to the arguments and returns the OrderBy itself for further configuration.
+func (f *FunctionCall) OrderByX(callback func(orderBy OrderBy)) Function {
+	if f.orderBy == nil {
+		f.orderBy = &OrderByClause{}
+	}
+	callback(f.orderBy)
+	return f
+}
+
+// WithinGroup adds the WITHIN GROUP (ORDER BY ...) clause, on an ascending order.
+func (f *FunctionCall) WithinGroup(fields ...interface{}) Function {
+	if f.withinGroup == nil {
+		f.withinGroup = &OrderByClause{}
+	}
+	f.withinGroup.Asc(fields...)
+	return f
+}
+
+// WithinGroupX adds the WITHIN GROUP (ORDER BY ...) clause and returns the OrderBy itself for further
+// configuration.
+func (f *FunctionCall) WithinGroupX(callback func(orderBy OrderBy)) Function {
+	if f.withinGroup == nil {
+		f.withinGroup = &OrderByClause{}
+	}
+	callback(f.withinGroup)
+	return f
+}
+
+// Filter adds the FILTER (WHERE ...) clause to an aggregate.
+func (f *FunctionCall) Filter(criteria ...FastSqlizer) Function {
+	f.filter = append(f.filter, criteria...)
+	return f
+}
+
+// ToSQLFast generates the SQL and returns it, alongside its params.
+func (f *FunctionCall) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
+	sb.WriteString(f.name)
+	sb.Write(sqlBracketOpen)
+	if f.distinct {
+		sb.Write(sqlSelectDistinctClause)
+	}
+	for idx, arg := range f.args {
+		if idx > 0 {
+			sb.Write(sqlComma)
+		}
+		err := RenderInterfaceAsSQL(sb, args, arg)
+		if err != nil {
+			return err
+		}
+	}
+	if f.orderBy != nil {
+		err := f.orderBy.ToSQLFast(sb, args)
+		if err != nil {
+			return err
+		}
+	}
+	sb.Write(sqlBracketClose)
+
+	if f.withinGroup != nil {
+		sb.Write(sqlFunctionWithinGroup)
+		err := f.withinGroup.writeFields(sb, args)
+		if err != nil {
+			return err
+		}
+		sb.Write(sqlBracketClose)
+	}
+
+	if len(f.filter) > 0 {
+		err := checkFeature(sb, FeatureAggregateFilter)
+		if err != nil {
+			return err
+		}
+		sb.Write(sqlFunctionFilter)
+		err = writeConditions(sb, args, "", "FILTER", f.filter)
+		if err != nil {
+			return err
+		}
+		sb.Write(sqlBracketClose)
+	}
+	return nil
+}
+
+// argument renders a single value as an argument.
+type argument struct {
+	value interface{}
+}
+
+// ToSQLFast generates the SQL and returns it, alongside its params.
+func (a *argument) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
+	if ok, err := renderRegisteredArg(sb, args, a.value); ok {
+		return err
+	}
+	sb.Write(sqlPredicatePlaceholder)
+	*args = append(*args, a.value)
+	return nil
+}
+
+// Arg binds `value` as an argument, rendering `?`. It is useful where values are rendered as SQL, like `Func`
+// arguments and select fields.
+func Arg(value interface{}) FastSqlizer {
+	return &argument{
+		value: value,
+	}
+}
+
+// stringLiteral renders a string as a SQL string literal.
+type stringLiteral struct {
+	value string
+}
+
+// ToSQLFast generates the SQL and returns it, alongside its params.
+func (l *stringLiteral) ToSQLFast(sb SQLWriter, _ *[]interface{}) error {
+	writeStringLiteral(sb, l.value)
+	return nil
+}
@@ -0,0 +1,79 @@
+package sqlf_test
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/jamillosantos/sqlf"
+	"github.com/jamillosantos/sqlf/testingutils"
+)
+
+var _ = Describe("Function", func() {
+	DescribeTable("calls",
+		func(sqlizer sqlf.FastSqlizer, expectedSQL string, expectedArgs []interface{}) {
+			sb, args := new(strings.Builder), make([]interface{}, 0)
+			err := sqlizer.ToSQLFast(sb, &args)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sb.String()).To(Equal(expectedSQL))
+			Expect(args).To(Equal(expectedArgs))
+		},
+		Entry("Func", sqlf.Func("COALESCE", "nickname", sqlf.Arg("anonymous")), "COALESCE(nickname, ?)", []interface{}{"anonymous"}),
+		Entry("Func without args", sqlf.Func("now"), "now()", []interface{}{}),
+		Entry("Count", sqlf.Count(), "COUNT(*)", []interface{}{}),
+		Entry("Count with arg", sqlf.Count("id"), "COUNT(id)", []interface{}{}),
+		Entry("CountDistinct", sqlf.CountDistinct("email"), "COUNT(DISTINCT email)", []interface{}{}),
+		Entry("Sum", sqlf.Sum("total"), "SUM(total)", []interface{}{}),
+		Entry("Avg", sqlf.Avg("total"), "AVG(total)", []interface{}{}),
+		Entry("Min", sqlf.Min("total"), "MIN(total)", []interface{}{}),
+		Entry("Max", sqlf.Max("total"), "MAX(total)", []interface{}{}),
+		Entry("nested", sqlf.Sum(sqlf.Func("COALESCE", "total", sqlf.Arg(0))), "SUM(COALESCE(total, ?))", []interface{}{0}),
+		Entry("StringAgg", sqlf.StringAgg("name", ",").OrderBy("name"), "string_agg(name, ',' ORDER BY name)", []interface{}{}),
+		Entry("StringAgg with DESC", sqlf.StringAgg("name", "', '").OrderByX(func(orderBy sqlf.OrderBy) {
+			orderBy.Desc("created_at")
+		}), "string_agg(name, ''', ''' ORDER BY created_at DESC)", []interface{}{}),
+		Entry("PercentileCont", sqlf.PercentileCont(0.5).WithinGroup("price"), "percentile_cont(?) WITHIN GROUP (ORDER BY price)", []interface{}{0.5}),
+		Entry("PercentileCont with DESC", sqlf.PercentileCont(0.9).WithinGroupX(func(orderBy sqlf.OrderBy) {
+			orderBy.Desc("price")
+		}), "percentile_cont(?) WITHIN GROUP (ORDER BY price DESC)", []interface{}{0.9}),
+		Entry("Filter", sqlf.Count().Filter(sqlf.Eq("status", "paid"), sqlf.Gt("total", 10)), "COUNT(*) FILTER (WHERE status = ? AND total > ?)", []interface{}{"paid", 10}),
+	)
+
+	It("should fail generating an errored argument", func() {
+		sb, args := new(strings.Builder), make([]interface{}, 0)
+		err := sqlf.Func("COALESCE", &testingutils.MockerSqlizer{
+			Err: errors.New("forced error"),
+		}).ToSQLFast(sb, &args)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("forced error"))
+	})
+
+	It("should fail generating an errored filter", func() {
+		sb, args := new(strings.Builder), make([]interface{}, 0)
+		err := sqlf.Count().Filter(&testingutils.MockerSqlizer{
+			Err: errors.New("forced error"),
+		}).ToSQLFast(sb, &args)
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(MatchError("sqlf: FILTER #1: forced error"))
+	})
+
+	It("should be used in the select, having and order by", func() {
+		sql, args, err := new(sqlf.SelectStatement).
+			Placeholder(sqlf.DollarPlaceholder).
+			Select("account_id", sqlf.Count().Filter(sqlf.Eq("status", "paid")), sqlf.PercentileCont(0.5).WithinGroup("total")).
+			From("orders").
+			GroupByX(func(groupBy sqlf.GroupBy) {
+				groupBy.Fields("account_id").HavingClause(sqlf.Gt(sqlf.Sum("total"), 100))
+			}).
+			OrderByX(func(orderBy sqlf.OrderBy) {
+				orderBy.Desc(sqlf.Sum("total"))
+			}).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(Equal([]interface{}{"paid", 0.5, 100}))
+		Expect(sql).To(Equal("SELECT account_id, COUNT(*) FILTER (WHERE status = $1), percentile_cont($2) WITHIN GROUP (ORDER BY total) FROM orders GROUP BY account_id HAVING SUM(total) > $3 ORDER BY SUM(total) DESC"))
+	})
+})
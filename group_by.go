@@ -37,14 +37,9 @@ func (groupBy *GroupByClause) ToSQLFast(sb SQLWriter, args *[]interface{}) error
 	}
 	if len(groupBy.having) > 0 {
 		sb.Write(sqlSelectHavingClause)
-		for idx, condition := range groupBy.having {
-			if idx > 0 {
-				sb.Write(sqlConditionAnd)
-			}
-			err := RenderInterfaceAsSQL(sb, args, condition)
-			if err != nil {
-				return err
-			}
+		err := writeConditions(sb, args, "", "HAVING", groupBy.having)
+		if err != nil {
+			return err
 		}
 	}
 	return nil
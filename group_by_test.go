@@ -69,6 +69,6 @@ var _ = Describe("GroupByClause", func() {
 		)
 		err := gb.ToSQLFast(sb, &args)
 		Expect(err).To(HaveOccurred())
-		Expect(err.Error()).To(Equal("forced error"))
+		Expect(err).To(MatchError("sqlf: HAVING #2: forced error"))
 	})
 })
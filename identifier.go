@@ -0,0 +1,132 @@
+package sqlf
+
+import "strings"
+
+var (
+	sqlIdentifierSeparator = []byte(".")
+	sqlIdentifierAll       = "*"
+)
+
+// IdentifierQuoter quotes identifiers (tables, columns and aliases) according to a database technology.
+type IdentifierQuoter interface {
+	// QuoteIdentifier writes `name` quoted into `sb`, escaping any quote inside of it.
+	QuoteIdentifier(sb SQLWriter, name string)
+}
+
+type identifierQuoter struct {
+	open    []byte
+	close   []byte
+	escaper *strings.Replacer
+}
+
+var (
+	// DoubleQuoteIdentifier quotes identifiers with double quotes (`"name"`), the SQL standard used by Postgres,
+	// SQLite and Oracle.
+	DoubleQuoteIdentifier IdentifierQuoter = &identifierQuoter{
+		open:    []byte(`"`),
+		close:   []byte(`"`),
+		escaper: strings.NewReplacer(`"`, `""`),
+	}
+
+	// BacktickIdentifier quotes identifiers with backticks (`name`), used by MySQL.
+	BacktickIdentifier IdentifierQuoter = &identifierQuoter{
+		open:    []byte("`"),
+		close:   []byte("`"),
+		escaper: strings.NewReplacer("`", "``"),
+	}
+
+	// BracketIdentifier quotes identifiers with brackets ([name]), used by SQL Server.
+	BracketIdentifier IdentifierQuoter = &identifierQuoter{
+		open:    []byte("["),
+		close:   []byte("]"),
+		escaper: strings.NewReplacer("]", "]]"),
+	}
+)
+
+// QuoteIdentifier writes `name` quoted into `sb`, escaping any quote inside of it.
+func (q *identifierQuoter) QuoteIdentifier(sb SQLWriter, name string) {
+	sb.Write(q.open)
+	q.escaper.WriteString(sb, name)
+	sb.Write(q.close)
+}
+
+// Identifier is a, possibly qualified, SQL identifier. Each part is quoted independently when rendered.
+//
+// The quoting style is defined by the statement being rendered (check `QuoteIdentifiers`), defaulting to the SQL
+// standard double quotes. As the quotes inside the name are escaped, it is safe to use with user supplied names.
+type Identifier []string
+
+// Ident creates an `Identifier` from its parts. Ex: `Ident("public", "users")` renders `"public"."users"`.
+//
+// A `*` part is not quoted. So, `Ident("u", "*")` renders `"u".*`.
+func Ident(parts ...string) Identifier {
+	return Identifier(parts)
+}
+
+// ToSQLFast generates the SQL and returns it, alongside its params.
+func (ident Identifier) ToSQLFast(sb SQLWriter, _ *[]interface{}) error {
+	quoter := identifierQuoterOf(sb)
+	for idx, part := range ident {
+		if idx > 0 {
+			sb.Write(sqlIdentifierSeparator)
+		}
+		if part == sqlIdentifierAll {
+			sb.WriteString(part)
+			continue
+		}
+		quoter.QuoteIdentifier(sb, part)
+	}
+	return nil
+}
+
+// identifierQuoterOf returns the `IdentifierQuoter` of the statement being rendered, falling back to the one of its
+// dialect.
+func identifierQuoterOf(sb SQLWriter) IdentifierQuoter {
+	ctx, ok := contextOf(sb)
+	if ok && ctx.quoter != nil {
+		return ctx.quoter
+	}
+	if ok && ctx.dialect != nil {
+		return ctx.dialect
+	}
+	return DoubleQuoteIdentifier
+}
+
+// writeTable writes a table name. If the statement quotes all identifiers, each part of the name (split by `.`)
+// is quoted. Otherwise, in strict mode, the name must be a plain identifier.
+func writeTable(sb SQLWriter, table string) error {
+	ctx, ok := contextOf(sb)
+	if !ok || !ctx.quoteAll {
+		err := checkName(sb, table)
+		if err != nil {
+			return err
+		}
+		sb.WriteString(table)
+		return nil
+	}
+	for idx, part := range strings.Split(table, ".") {
+		if idx > 0 {
+			sb.Write(sqlIdentifierSeparator)
+		}
+		ctx.quoter.QuoteIdentifier(sb, part)
+	}
+	return nil
+}
+
+// writeAlias writes a table alias. If the statement quotes all identifiers, the alias is quoted. Otherwise, in
+// strict mode, the alias must be a plain identifier.
+func writeAlias(sb SQLWriter, alias string) error {
+	ctx, ok := contextOf(sb)
+	if !ok || !ctx.quoteAll {
+		if ctx.strict && !isPlainIdentifier(alias) {
+			return &UnsafeSQLError{
+				SQL:    alias,
+				Reason: "not a valid alias, enable QuoteIdentifiers for dynamic names",
+			}
+		}
+		sb.WriteString(alias)
+		return nil
+	}
+	ctx.quoter.QuoteIdentifier(sb, alias)
+	return nil
+}
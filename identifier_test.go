@@ -0,0 +1,113 @@
+package sqlf_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/jamillosantos/sqlf"
+)
+
+var _ = Describe("Identifier", func() {
+	DescribeTable("quoters",
+		func(quoter sqlf.IdentifierQuoter, name, expected string) {
+			sb := new(strings.Builder)
+			quoter.QuoteIdentifier(sb, name)
+			Expect(sb.String()).To(Equal(expected))
+		},
+		Entry("double quotes", sqlf.DoubleQuoteIdentifier, "user", `"user"`),
+		Entry("double quotes escaping", sqlf.DoubleQuoteIdentifier, `a"b`, `"a""b"`),
+		Entry("backticks", sqlf.BacktickIdentifier, "order", "`order`"),
+		Entry("backticks escaping", sqlf.BacktickIdentifier, "a`b", "`a``b`"),
+		Entry("brackets", sqlf.BracketIdentifier, "user", "[user]"),
+		Entry("brackets escaping", sqlf.BracketIdentifier, "a]b", "[a]]b]"),
+	)
+
+	It("should render a qualified identifier with double quotes by default", func() {
+		sb, args := new(strings.Builder), make([]interface{}, 0)
+		err := sqlf.Ident("public", "Users").ToSQLFast(sb, &args)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(BeEmpty())
+		Expect(sb.String()).To(Equal(`"public"."Users"`))
+	})
+
+	It("should not quote *", func() {
+		sb, args := new(strings.Builder), make([]interface{}, 0)
+		err := sqlf.Ident("u", "*").ToSQLFast(sb, &args)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sb.String()).To(Equal(`"u".*`))
+	})
+
+	It("should escape an injection attempt", func() {
+		sb, args := new(strings.Builder), make([]interface{}, 0)
+		err := sqlf.Ident(`name"; DROP TABLE users; --`).ToSQLFast(sb, &args)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sb.String()).To(Equal(`"name""; DROP TABLE users; --"`))
+	})
+
+	It("should use the quoter of the statement for identifiers", func() {
+		sql, args, err := new(sqlf.SelectStatement).
+			QuoteIdentifiers(sqlf.BacktickIdentifier).
+			Select(sqlf.Ident("o", "id"), sqlf.As("total", "order total")).
+			From("shop.order", "o").
+			InnerJoin("user", "u").On("u.id = o.user_id").
+			Where("o.id = ?", 1).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(Equal([]interface{}{1}))
+		Expect(sql).To(Equal("SELECT `o`.`id`, total AS `order total` FROM `shop`.`order` AS `o` INNER JOIN `user` AS `u` ON u.id = o.user_id WHERE o.id = ?"))
+	})
+
+	It("should quote identifiers of nested statements with the outer quoter", func() {
+		sql, _, err := new(sqlf.SelectStatement).
+			QuoteIdentifiers(sqlf.BracketIdentifier).
+			From("user").
+			WhereCriteria(sqlf.In(sqlf.Ident("id"), new(sqlf.SelectStatement).Select(sqlf.Ident("user_id")).From("order"))).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("SELECT * FROM [user] WHERE [id] IN (SELECT [user_id] FROM [order])"))
+	})
+
+	It("should quote the table of an insert", func() {
+		sql, _, err := new(sqlf.InsertStatement).
+			QuoteIdentifiers(sqlf.DoubleQuoteIdentifier).
+			Into("user", sqlf.Ident("name")).
+			Values("Name 1").
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal(`INSERT INTO "user" ("name") VALUES (?)`))
+	})
+
+	It("should quote the table of an update", func() {
+		sql, _, err := new(sqlf.UpdateStatement).
+			QuoteIdentifiers(sqlf.DoubleQuoteIdentifier).
+			Table("user", "u").
+			Set(sqlf.Ident("name"), "Name 1").
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal(`UPDATE "user" AS "u" SET "name" = ?`))
+	})
+
+	It("should quote the table of a delete", func() {
+		sql, _, err := new(sqlf.DeleteStatement).
+			Placeholder(sqlf.DollarPlaceholder).
+			QuoteIdentifiers(sqlf.DoubleQuoteIdentifier).
+			From("user", "u").
+			WhereClause(sqlf.Eq(sqlf.Ident("u", "id"), 1)).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal(`DELETE FROM "user" AS "u" WHERE "u"."id" = $1`))
+	})
+
+	It("should quote identifiers of statements created by the builder", func() {
+		sql, _, err := sqlf.NewBuilder().
+			QuoteIdentifiers(sqlf.BacktickIdentifier).
+			Select().
+			From("order").
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("SELECT * FROM `order`"))
+	})
+})
@@ -1,26 +1,62 @@
 package sqlf
 
+// InsertUpdate describes the update action of the conflict clause of an insert.
 type InsertUpdate interface {
+	// Set defines what fields will be updated, alongside its values, in a alternating order (the same as
+	// `Update.Set`). Use `Excluded` to reference the value proposed for insertion.
 	Set(fieldsAndValues ...interface{}) InsertUpdate
+
+	// Where appends a condition for the update to happen. The conditions added will use the AND operator.
 	Where(condition string, args ...interface{}) InsertUpdate
+
+	// WhereClause appends any FastSqlizer as a condition for the update to happen.
 	WhereClause(conditions ...FastSqlizer) InsertUpdate
 }
 
 // InsertConflict describes the conflict statement for insertion.
 type InsertConflict interface {
+	// Target defines the conflict target: the columns (Ex: "email" or "tenant_id, email") or any `FastSqlizer`.
+	// It is ignored by MySQL, which uses any unique key.
 	Target(target interface{}) InsertConflict
+
+	// DoNothing ignores the conflicting records. It is the default action.
 	DoNothing() InsertConflict
+
+	// Update updates the conflicting records.
 	Update(callback func(InsertUpdate)) InsertConflict
 }
 
 // Insert describes how a insert will behave into the sqlf.
 type Insert interface {
 	Sqlizer
+	InterpolatedSqlizer
+	Runner
 	FastSqlizer
 
 	// Placeholder defines the placeholder format that should be used for this insert statement.
 	Placeholder(placeholder PlaceholderFormatFactory) Insert
 
+	// QuoteIdentifiers enables quoting the table of the insert automatically, using `quoter`. The `quoter` is also
+	// used for the `Identifier`s and aliases (`As`) rendered as part of the insert.
+	QuoteIdentifiers(quoter IdentifierQuoter) Insert
+
+	// Dialect defines the database technology the insert is rendered for. The dialect provides the placeholder
+	// format (when `Placeholder` is not defined), the identifier quoting and the syntax of the features that differ
+	// between databases. Using a feature the dialect does not support returns an `UnsupportedFeatureError`.
+	Dialect(dialect Dialect) Insert
+
+	// Strict enables the strict mode for the insert, and the statements nested into it. Check `Select.Strict`.
+	Strict() Insert
+
+	// EmptySlices defines how the empty slice arguments of the conditions are rendered. Check `Select.EmptySlices`.
+	EmptySlices(style EmptySliceStyle) Insert
+
+	// Bind defines the values of the named parameters of the conditions. Check `Select.Bind`.
+	Bind(values interface{}) Insert
+
+	// RunWith defines the `Executor` used to run the insert. Check `Select.RunWith`.
+	RunWith(db Executor) Insert
+
 	// Into defines what table the data will be inserted on. `fields` are the same as `Fields` method.
 	Into(tableName string, fields ...interface{}) Insert
 
@@ -45,6 +81,18 @@ type Insert interface {
 	//
 	Values(values ...interface{}) Insert
 
+	// Struct defines the fields and values of the insert from the struct `value` (or a pointer to it), replacing
+	// the ones already defined. Check `StructOption` for how the columns are picked. Ex:
+	//
+	//     b.Insert("users").Struct(&user, sqlf.ReturningGenerated())
+	//
+	Struct(value interface{}, opts ...StructOption) Insert
+
+	// Structs defines the fields and values of the insert from a slice of structs (or of pointers to them),
+	// inserting one record per struct. All the structs must pick the same columns, otherwise rendering fails with
+	// `ErrInvalidStruct` (Ex: a column tagged with `omitempty` that is empty for only some of them). Check `Struct`.
+	Structs(values interface{}, opts ...StructOption) Insert
+
 	// Select defines a select that will be inserted.
 	//
 	// Below an example of how this would be used in plain SQL. Ex:
@@ -55,10 +103,21 @@ type Insert interface {
 	//
 	Select(callback func(Select)) Insert
 
-	// Returning defines the RETURNING clause defined for Postgres.
+	// Returning defines the RETURNING clause defined for Postgres (and SQLite).
 	Returning(fields ...interface{}) Insert
 
-	// OnConflict defines the ON CONFLICT clause for Postgres.
+	// OnConflict defines what happens when the insert conflicts with an existing record (an upsert). It renders
+	// `ON CONFLICT ... DO ...` for Postgres and SQLite, or `ON DUPLICATE KEY UPDATE ...` for MySQL, depending on
+	// the `Dialect`. Without a dialect, the Postgres syntax is used.
+	//
+	// Example:
+	//
+	//     i.OnConflict(func(c sqlf.InsertConflict) {
+	//         c.Target("email").Update(func(u sqlf.InsertUpdate) {
+	//             u.Set("name", sqlf.Excluded("name"))
+	//         })
+	//     })
+	//
 	OnConflict(callback func(InsertConflict)) Insert
 
 	// Suffix defines a suffix that will be appended at the end of the insert clause. This can be used to extend the
@@ -0,0 +1,138 @@
+package sqlf
+
+var (
+	sqlInsertOnConflictClause     = []byte(" ON CONFLICT")
+	sqlInsertDoNothingClause      = []byte(" DO NOTHING")
+	sqlInsertDoUpdateClause       = []byte(" DO UPDATE SET ")
+	sqlInsertOnDuplicateKeyClause = []byte(" ON DUPLICATE KEY UPDATE ")
+)
+
+// InsertConflictClause is the default implementation of the `InsertConflict` interface.
+type InsertConflictClause struct {
+	target interface{}
+	update *InsertUpdateClause
+}
+
+// Target defines the conflict target: the columns (Ex: "email" or "tenant_id, email") or any `FastSqlizer`.
+// It is ignored by MySQL, which uses any unique key.
+func (conflict *InsertConflictClause) Target(target interface{}) InsertConflict {
+	conflict.target = target
+	return conflict
+}
+
+// DoNothing ignores the conflicting records. It is the default action.
+func (conflict *InsertConflictClause) DoNothing() InsertConflict {
+	conflict.update = nil
+	return conflict
+}
+
+// Update updates the conflicting records.
+func (conflict *InsertConflictClause) Update(callback func(InsertUpdate)) InsertConflict {
+	conflict.update = &InsertUpdateClause{}
+	callback(conflict.update)
+	return conflict
+}
+
+// ToSQLFast generates the SQL and returns it, alongside its params.
+func (conflict *InsertConflictClause) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
+	err := checkFeature(sb, FeatureUpsert)
+	if err != nil {
+		return err
+	}
+	if conflict.update == nil {
+		err = checkFeature(sb, FeatureUpsertDoNothing)
+	} else if len(conflict.update.where) > 0 {
+		err = checkFeature(sb, FeatureUpsertWhere)
+	}
+	if err != nil {
+		return err
+	}
+
+	if dialect := dialectOf(sb); dialect != nil && dialect.Upsert() == UpsertOnDuplicateKey {
+		// Writing >> ON DUPLICATE KEY UPDATE <FIELDS> <<
+		sb.Write(sqlInsertOnDuplicateKeyClause)
+		return conflict.update.writeSet(sb, args)
+	}
+
+	// Writing >> ON CONFLICT (<TARGET>) <<
+	sb.Write(sqlInsertOnConflictClause)
+	if conflict.target != nil {
+		sb.Write(sqlSpace)
+		sb.Write(sqlBracketOpen)
+		err := RenderInterfaceAsSQL(sb, args, conflict.target)
+		if err != nil {
+			return err
+		}
+		sb.Write(sqlBracketClose)
+	}
+
+	if conflict.update == nil {
+		// Writing on conflict (<target>) >> DO NOTHING <<
+		sb.Write(sqlInsertDoNothingClause)
+		return nil
+	}
+
+	// Writing on conflict (<target>) >> DO UPDATE SET <FIELDS> WHERE <CONDITIONS> <<
+	sb.Write(sqlInsertDoUpdateClause)
+	err = conflict.update.writeSet(sb, args)
+	if err != nil {
+		return err
+	}
+	if len(conflict.update.where) > 0 {
+		sb.Write(sqlWhereClause)
+		err := writeConditions(sb, args, "", "WHERE", conflict.update.where)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InsertUpdateClause is the default implementation of the `InsertUpdate` interface.
+type InsertUpdateClause struct {
+	fields []interface{}
+	where  []FastSqlizer
+}
+
+// Set defines what fields will be updated, alongside its values, in a alternating order (the same as
+// `Update.Set`). Use `Excluded` to reference the value proposed for insertion.
+func (update *InsertUpdateClause) Set(fieldsAndValues ...interface{}) InsertUpdate {
+	update.fields = append(update.fields, fieldsAndValues...)
+	return update
+}
+
+// Where appends a condition for the update to happen. The conditions added will use the AND operator.
+func (update *InsertUpdateClause) Where(condition string, args ...interface{}) InsertUpdate {
+	update.where = append(update.where, Condition(condition, args...))
+	return update
+}
+
+// WhereClause appends any FastSqlizer as a condition for the update to happen.
+func (update *InsertUpdateClause) WhereClause(conditions ...FastSqlizer) InsertUpdate {
+	update.where = append(update.where, conditions...)
+	return update
+}
+
+// writeSet writes the `field = value` pairs. Values that are `FastSqlizer`s (like `Excluded`) are rendered inline,
+// anything else is bound as an argument.
+func (update *InsertUpdateClause) writeSet(sb SQLWriter, args *[]interface{}) error {
+	lenFields := len(update.fields)
+	if lenFields%2 != 0 {
+		return ErrUpdateInvalidFieldValuePairCount
+	}
+	for i := 0; i < lenFields; i += 2 {
+		if i > 0 {
+			sb.Write(sqlComma)
+		}
+		err := RenderInterfaceAsSQL(sb, args, update.fields[i])
+		if err != nil {
+			return err
+		}
+		sb.Write(sqlUpdateAssignOperation)
+		err = RenderInterfaceAsArg(sb, args, update.fields[i+1])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package sqlf_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/jamillosantos/sqlf"
+)
+
+var _ = Describe("Insert OnConflict", func() {
+	It("should generate ON CONFLICT DO NOTHING by default", func() {
+		sql, args, err := new(sqlf.InsertStatement).
+			Into("users", "email").
+			Values("email@email.com").
+			OnConflict(func(c sqlf.InsertConflict) {}).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(Equal([]interface{}{"email@email.com"}))
+		Expect(sql).To(Equal("INSERT INTO users (email) VALUES (?) ON CONFLICT DO NOTHING"))
+	})
+
+	It("should generate ON CONFLICT with a target and DO UPDATE", func() {
+		sql, args, err := sqlf.NewBuilder().
+			Dialect(sqlf.Postgres).
+			Insert("users", "email", "name").
+			Values("email@email.com", "Name").
+			OnConflict(func(c sqlf.InsertConflict) {
+				c.Target("email").Update(func(u sqlf.InsertUpdate) {
+					u.Set("name", sqlf.Excluded("name"), "updated", 1).Where("users.locked = ?", false)
+				})
+			}).
+			Returning("id").
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(Equal([]interface{}{"email@email.com", "Name", 1, false}))
+		Expect(sql).To(Equal("INSERT INTO users (email, name) VALUES ($1,$2) ON CONFLICT (email) DO UPDATE SET name = EXCLUDED.name, updated = $3 WHERE users.locked = $4 RETURNING id"))
+	})
+
+	It("should generate ON DUPLICATE KEY UPDATE for MySQL", func() {
+		sql, args, err := sqlf.NewBuilder().
+			Dialect(sqlf.MySQL).
+			Insert("users", "email", "name").
+			Values("email@email.com", "Name").
+			OnConflict(func(c sqlf.InsertConflict) {
+				c.Target("email").Update(func(u sqlf.InsertUpdate) {
+					u.Set("name", sqlf.Excluded("name"))
+				})
+			}).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(Equal([]interface{}{"email@email.com", "Name"}))
+		Expect(sql).To(Equal("INSERT INTO users (email, name) VALUES (?,?) ON DUPLICATE KEY UPDATE name = VALUES(name)"))
+	})
+
+	It("should quote the excluded column when the statement quotes all identifiers", func() {
+		sql, _, err := sqlf.NewBuilder().
+			Dialect(sqlf.Postgres).
+			Insert("users", "email", "name").
+			QuoteIdentifiers(sqlf.DoubleQuoteIdentifier).
+			Values("email@email.com", "Name").
+			OnConflict(func(c sqlf.InsertConflict) {
+				c.Target("email").Update(func(u sqlf.InsertUpdate) {
+					u.Set("name", sqlf.Excluded(`na"me`))
+				})
+			}).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(ContainSubstring(`SET name = EXCLUDED."na""me"`))
+
+		sql, _, err = sqlf.NewBuilder().
+			Dialect(sqlf.MySQL).
+			Insert("users", "email", "name").
+			QuoteIdentifiers(sqlf.MySQL).
+			Values("email@email.com", "Name").
+			OnConflict(func(c sqlf.InsertConflict) {
+				c.Update(func(u sqlf.InsertUpdate) {
+					u.Set("name", sqlf.Excluded("name"))
+				})
+			}).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(HaveSuffix("ON DUPLICATE KEY UPDATE name = VALUES(`name`)"))
+	})
+
+	It("should refuse an excluded column that is not a plain identifier in strict mode", func() {
+		_, _, err := sqlf.NewBuilder().
+			Strict().
+			Dialect(sqlf.Postgres).
+			Insert("users", "email", "name").
+			Values("email@email.com", "Name").
+			OnConflict(func(c sqlf.InsertConflict) {
+				c.Target("email").Update(func(u sqlf.InsertUpdate) {
+					u.Set("name", sqlf.Excluded("name; DROP TABLE users"))
+				})
+			}).
+			ToSQL()
+		Expect(errors.Is(err, sqlf.ErrUnsafeSQL)).To(BeTrue())
+	})
+
+	It("should fail DO NOTHING for MySQL", func() {
+		_, _, err := new(sqlf.InsertStatement).
+			Dialect(sqlf.MySQL).
+			Into("users", "email").
+			Values("email@email.com").
+			OnConflict(func(c sqlf.InsertConflict) { c.DoNothing() }).
+			ToSQL()
+		var featureErr *sqlf.UnsupportedFeatureError
+		Expect(errors.As(err, &featureErr)).To(BeTrue())
+		Expect(*featureErr).To(Equal(sqlf.UnsupportedFeatureError{Dialect: "mysql", Feature: sqlf.FeatureUpsertDoNothing}))
+	})
+
+	It("should fail an upsert for SQL Server", func() {
+		_, _, err := new(sqlf.InsertStatement).
+			Dialect(sqlf.SQLServer).
+			Into("users", "email").
+			Values("email@email.com").
+			OnConflict(func(c sqlf.InsertConflict) {}).
+			ToSQL()
+		Expect(errors.Is(err, sqlf.ErrUnsupportedFeature)).To(BeTrue())
+		Expect(err).To(MatchError("sqlf: INSERT ON CONFLICT: the sqlserver dialect does not support upsert"))
+	})
+
+	It("should fail with an odd number of fields and values", func() {
+		_, _, err := new(sqlf.InsertStatement).
+			Into("users", "email").
+			Values("email@email.com").
+			OnConflict(func(c sqlf.InsertConflict) {
+				c.Update(func(u sqlf.InsertUpdate) { u.Set("name") })
+			}).
+			ToSQL()
+		Expect(err).To(MatchError(sqlf.ErrUpdateInvalidFieldValuePairCount))
+	})
+})
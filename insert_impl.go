@@ -1,8 +1,12 @@
 package sqlf
 
 import (
+	"context"
+	"database/sql"
 	"errors"
-	"strings"
+	"fmt"
+	"reflect"
+	"strconv"
 )
 
 var (
@@ -22,13 +26,22 @@ var (
 // InsertStatement is the default implementation of the `Insert` interface.
 type InsertStatement struct {
 	placeholderFormat PlaceholderFormatFactory
+	quoter            IdentifierQuoter
+	dialect           Dialect
+	strict            bool
+	emptySlices       EmptySliceStyle
+	bindings          *bindings
+	registry          *Registry
+	executor          Executor
 	tableName         string
 	fields            []interface{}
 	values            []interface{}
 	selectStatement   Select
 	returning         []interface{}
-	suffix            string
-	suffixArgs        []interface{}
+	conflict          *InsertConflictClause
+	suffix            FastSqlizer
+	structErr         error
+	returningTargets  []reflect.Value
 }
 
 // Placeholder defines the placeholder format that should be used for this insert statement.
@@ -37,6 +50,70 @@ func (insert *InsertStatement) Placeholder(placeholder PlaceholderFormatFactory)
 	return insert
 }
 
+// QuoteIdentifiers enables quoting the table of the insert automatically, using `quoter`. The `quoter` is also
+// used for the `Identifier`s and aliases (`As`) rendered as part of the insert.
+func (insert *InsertStatement) QuoteIdentifiers(quoter IdentifierQuoter) Insert {
+	insert.quoter = quoter
+	return insert
+}
+
+// Dialect defines the database technology the insert is rendered for. The dialect provides the placeholder
+// format (when `Placeholder` is not defined), the identifier quoting and the syntax of the features that differ
+// between databases. Using a feature the dialect does not support returns an `UnsupportedFeatureError`.
+func (insert *InsertStatement) Dialect(dialect Dialect) Insert {
+	insert.dialect = dialect
+	return insert
+}
+
+// Strict enables the strict mode for the insert, and the statements nested into it. Check `Select.Strict`.
+func (insert *InsertStatement) Strict() Insert {
+	insert.strict = true
+	return insert
+}
+
+// EmptySlices defines how the empty slice arguments of the conditions are rendered. Check `Select.EmptySlices`.
+func (insert *InsertStatement) EmptySlices(style EmptySliceStyle) Insert {
+	insert.emptySlices = style
+	return insert
+}
+
+// Bind defines the values of the named parameters of the conditions. Check `Select.Bind`.
+func (insert *InsertStatement) Bind(values interface{}) Insert {
+	insert.bindings = newBindings(values)
+	return insert
+}
+
+// RunWith defines the `Executor` used to run the insert. Check `Select.RunWith`.
+func (insert *InsertStatement) RunWith(db Executor) Insert {
+	insert.executor = db
+	return insert
+}
+
+// ExecContext executes the insert with its `Executor`, without returning any rows.
+//
+// When the insert was defined by `Struct` or `Structs` with `ReturningGenerated`, the generated columns are scanned
+// back into the structs. It fails with `ErrReturningMismatch` when the rows returned are not one per struct.
+func (insert *InsertStatement) ExecContext(ctx context.Context) (sql.Result, error) {
+	if len(insert.returningTargets) > 0 {
+		rows, err := queryContext(ctx, insert.executor, insert)
+		if err != nil {
+			return nil, err
+		}
+		return scanReturning(rows, insert.returningTargets, true)
+	}
+	return execContext(ctx, insert.executor, insert)
+}
+
+// QueryContext executes the insert with its `Executor`, returning its rows.
+func (insert *InsertStatement) QueryContext(ctx context.Context) (*sql.Rows, error) {
+	return queryContext(ctx, insert.executor, insert)
+}
+
+// QueryRowContext executes the insert with its `Executor`, returning at most one row. Check `Row`.
+func (insert *InsertStatement) QueryRowContext(ctx context.Context) *Row {
+	return queryRowContext(ctx, insert.executor, insert)
+}
+
 // Into defines what table the data will be inserted on. `fields` are the same as `Fields` method.
 func (insert *InsertStatement) Into(tableName string, fields ...interface{}) Insert {
 	insert.tableName = tableName
@@ -84,6 +161,41 @@ func (insert *InsertStatement) Values(values ...interface{}) Insert {
 	return insert
 }
 
+// Struct defines the fields and values of the insert from the struct `value` (or a pointer to it), replacing the
+// ones already defined. Check `StructOption` for how the columns are picked. Ex:
+//
+//     b.Insert("users").Struct(&user, sqlf.ReturningGenerated())
+//
+func (insert *InsertStatement) Struct(value interface{}, opts ...StructOption) Insert {
+	return insert.setStructs(value, false, opts)
+}
+
+// Structs defines the fields and values of the insert from a slice of structs (or of pointers to them), inserting
+// one record per struct. All the structs must pick the same columns. Check `Struct`.
+func (insert *InsertStatement) Structs(values interface{}, opts ...StructOption) Insert {
+	return insert.setStructs(values, true, opts)
+}
+
+// setStructs defines the fields, values and the returning of the insert from the structs of `value`. Errors are
+// returned when the insert is rendered.
+func (insert *InsertStatement) setStructs(value interface{}, many bool, opts []StructOption) Insert {
+	columns, err := pickStructColumns(value, many, true, opts)
+	if err == nil && len(columns.columns) == 0 {
+		err = fmt.Errorf("%w: no columns to insert from %T", ErrInvalidStruct, value)
+	}
+	insert.structErr = err
+	if err != nil {
+		return insert
+	}
+	insert.fields = columns.columns
+	insert.values = columns.values
+	insert.returningTargets = columns.targets
+	if len(columns.returning) > 0 {
+		insert.returning = columns.returning
+	}
+	return insert
+}
+
 // Select defines a select that will be inserted.
 //
 // Below an example of how this would be used in plain SQL. Ex:
@@ -100,28 +212,35 @@ func (insert *InsertStatement) Select(callback func(Select)) Insert {
 	return insert
 }
 
-// Returning defines the RETURNING clause defined for Postgres.
+// Returning defines the RETURNING clause defined for Postgres (and SQLite).
 func (insert *InsertStatement) Returning(fields ...interface{}) Insert {
 	insert.returning = fields
 	return insert
 }
 
-// OnConflict defines the ON CONFLICT clause for Postgres.
+// OnConflict defines what happens when the insert conflicts with an existing record (an upsert). It renders
+// `ON CONFLICT ... DO ...` for Postgres and SQLite, or `ON DUPLICATE KEY UPDATE ...` for MySQL, depending on
+// the `Dialect`. Without a dialect, the Postgres syntax is used.
 func (insert *InsertStatement) OnConflict(callback func(InsertConflict)) Insert {
-	panic("not implemented") // TODO(Jota): To implement
+	insert.conflict = &InsertConflictClause{}
+	callback(insert.conflict)
+	return insert
 }
 
 // Suffix defines a suffix that will be appended at the end of the insert clause. This can be used to extend the
 // uses (like "ON DUPLICATE KEY" on MySQL) for other database technologies.
 func (insert *InsertStatement) Suffix(suffix string, args ...interface{}) Insert {
-	insert.suffix = suffix
-	insert.suffixArgs = args
+	if suffix == "" {
+		insert.suffix = nil
+		return insert
+	}
+	insert.suffix = Condition(suffix, args...)
 	return insert
 }
 
 // ToSQL generates the SQL and returns it, alongside its params.
 func (insert *InsertStatement) ToSQL() (string, []interface{}, error) {
-	sb := new(strings.Builder)
+	sb := new(renderBuilder)
 	args := make([]interface{}, 0)
 	err := insert.ToSQLFast(sb, &args)
 	if err != nil {
@@ -130,22 +249,57 @@ func (insert *InsertStatement) ToSQL() (string, []interface{}, error) {
 	return sb.String(), args, nil
 }
 
+// ToInterpolatedSQL generates the SQL with its params inlined as literals, for logging and debugging. Check
+// `InterpolatedSQL`.
+func (insert *InsertStatement) ToInterpolatedSQL() (InterpolatedSQL, error) {
+	return ToInterpolatedSQL(insert)
+}
+
+// statementDialect returns the dialect of the insert, used to write the literals of `ToInterpolatedSQL`.
+func (insert *InsertStatement) statementDialect() Dialect {
+	return insert.dialect
+}
+
 // ToSQLFast generates the SQL and returns it, alongside its params.
 func (insert *InsertStatement) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
-	if insert.placeholderFormat != nil {
-		sb = insert.placeholderFormat.Wrap(sb)
+	session := beginRender(sb, args, insert.placeholderFormat, renderContext{
+		dialect:     insert.dialect,
+		quoter:      insert.quoter,
+		quoteAll:    insert.quoter != nil,
+		strict:      insert.strict,
+		emptySlices: insert.emptySlices,
+		bindings:    insert.bindings,
+		registry:    insert.registry,
+	})
+	return session.end(insert.render(session.sb, args))
+}
+
+// render writes the insert into the already prepared `sb`.
+func (insert *InsertStatement) render(sb SQLWriter, args *[]interface{}) error {
+	err := insert.bindings.bindError()
+	if err != nil {
+		return renderError("INSERT", "BIND", nil, err)
+	}
+	if insert.structErr != nil {
+		return renderError("INSERT", "STRUCT", nil, insert.structErr)
 	}
 	lenFields := len(insert.fields)
 	// if the selectStatement is not defined AND if the values count is multiple of the fields count.
 	if insert.selectStatement == nil && len(insert.values)%lenFields != 0 {
-		return ErrMismatchFieldsAndValuesCount
+		// The incomplete record is the last one.
+		row := len(insert.values) / lenFields
+		return renderError("INSERT", "VALUES row "+strconv.Itoa(row+1), insert.values[row*lenFields:],
+			ErrMismatchFieldsAndValuesCount)
 	}
 
 	// Writing >> INSERT INTO <<
 	sb.Write(sqlInsertStatement)
 
 	// Writing insert into >> <TABLENAME> <<
-	sb.WriteString(insert.tableName)
+	err = writeTable(sb, insert.tableName)
+	if err != nil {
+		return renderError("INSERT", "INTO", insert.tableName, err)
+	}
 	sb.Write(sqlSpace)
 
 	// Writing insert into <tablename> >> (<FIELDS>) <<
@@ -156,7 +310,7 @@ func (insert *InsertStatement) ToSQLFast(sb SQLWriter, args *[]interface{}) erro
 		}
 		err := RenderInterfaceAsSQL(sb, args, field)
 		if err != nil {
-			return err
+			return renderError("INSERT", clauseAt("FIELDS", idx), field, err)
 		}
 	}
 	sb.Write(sqlBracketClose)
@@ -165,26 +319,50 @@ func (insert *InsertStatement) ToSQLFast(sb SQLWriter, args *[]interface{}) erro
 		// Writting insert into <tablename> (<fields>) >> VALUES (<VALUES>) <<
 		sb.Write(sqlInsertValuesClause)
 		recordCount := len(insert.values) / lenFields
+		registered := registryOf(sb) != nil
 		for i := 0; i < recordCount; i++ {
 			if i > 0 {
 				sb.Write(sqlComma)
 			}
 			sb.Write(sqlBracketOpen)
-			sb.WriteString(Placeholders(lenFields))
+			if registered {
+				// The registered types render their own placeholders.
+				row := insert.values[i*lenFields : (i+1)*lenFields]
+				err = writeRow(sb, args, row)
+				if err != nil {
+					return renderError("INSERT", "VALUES row "+strconv.Itoa(i+1), row, err)
+				}
+			} else {
+				sb.WriteString(Placeholders(lenFields))
+			}
 			sb.Write(sqlBracketClose)
 		}
-		*args = append(*args, insert.values...)
+		if !registered {
+			*args = append(*args, insert.values...)
+		}
 	} else {
 		// Writting insert into <tablename> (<fields>) >> SELECT ... FROM ... <<
 		sb.Write(sqlSpace)
 		err := insert.selectStatement.ToSQLFast(sb, args)
 		if err != nil {
-			return err
+			return renderError("INSERT", "SELECT", insert.selectStatement, err)
+		}
+	}
+
+	if insert.conflict != nil {
+		// Writting insert into ... values (...) >> ON CONFLICT ... <<
+		err := insert.conflict.ToSQLFast(sb, args)
+		if err != nil {
+			return renderError("INSERT", "ON CONFLICT", insert.conflict, err)
 		}
 	}
 
 	if len(insert.returning) > 0 {
 		// Writting insert into ... values (...) >> RETURNING <fields> <<
+		err := checkFeature(sb, FeatureReturning)
+		if err != nil {
+			return renderError("INSERT", "RETURNING", nil, err)
+		}
 
 		sb.Write(sqlInsertReturningClause)
 		//
@@ -194,16 +372,36 @@ func (insert *InsertStatement) ToSQLFast(sb SQLWriter, args *[]interface{}) erro
 			}
 			err := RenderInterfaceAsSQL(sb, args, field)
 			if err != nil {
-				return err
+				return renderError("INSERT", clauseAt("RETURNING", idx), field, err)
 			}
 		}
 	}
 
-	if insert.suffix != "" {
+	if insert.suffix != nil {
 		sb.Write(sqlSpace)
-		sb.WriteString(insert.suffix)
-		if len(insert.suffixArgs) > 0 {
-			*args = append(*args, insert.suffixArgs...)
+		err := insert.suffix.ToSQLFast(sb, args)
+		if err != nil {
+			return renderError("INSERT", "SUFFIX", insert.suffix, err)
+		}
+	}
+	return nil
+}
+
+// writeRow writes the values of a row of the VALUES clause, rendering the registered types by their
+// `TypeRenderer.Arg` and binding the other values unchanged.
+func writeRow(sb SQLWriter, args *[]interface{}, row []interface{}) error {
+	for idx, value := range row {
+		if idx > 0 {
+			// Same separator of `Placeholders`.
+			sb.WriteByte(',')
+		}
+		ok, err := renderRegisteredArg(sb, args, value)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			sb.Write(sqlPredicatePlaceholder)
+			*args = append(*args, value)
 		}
 	}
 	return nil
@@ -43,7 +43,7 @@ var _ = Describe("Insert", func() {
 		Expect(err).To(HaveOccurred())
 		Expect(args).To(BeNil())
 		Expect(sql).To(BeEmpty())
-		Expect(err.Error()).To(Equal("forced error"))
+		Expect(err).To(MatchError("sqlf: INSERT RETURNING #1: forced error"))
 	})
 
 	It("should fail inserting an errored field", func() {
@@ -55,7 +55,7 @@ var _ = Describe("Insert", func() {
 		Expect(err).To(HaveOccurred())
 		Expect(args).To(BeNil())
 		Expect(sql).To(BeEmpty())
-		Expect(err.Error()).To(Equal("forced error"))
+		Expect(err).To(MatchError("sqlf: INSERT FIELDS #2: forced error"))
 	})
 
 	It("should generate a multi INSERT INTO", func() {
@@ -72,7 +72,8 @@ var _ = Describe("Insert", func() {
 		Expect(err).To(HaveOccurred())
 		Expect(args).To(BeNil())
 		Expect(sql).To(BeEmpty())
-		Expect(err).To(Equal(sqlf.ErrMismatchFieldsAndValuesCount))
+		Expect(err).To(MatchError(sqlf.ErrMismatchFieldsAndValuesCount))
+		Expect(err).To(MatchError("sqlf: INSERT VALUES row 2: the amount values is not compatible with the amount of fields"))
 	})
 
 	It("should generate a multi INSERT INTO adding fields", func() {
@@ -103,7 +104,7 @@ var _ = Describe("Insert", func() {
 		Expect(err).To(HaveOccurred())
 		Expect(args).To(BeNil())
 		Expect(sql).To(BeEmpty())
-		Expect(err.Error()).To(Equal("forced error"))
+		Expect(err).To(MatchError("sqlf: INSERT SELECT > SELECT FIELDS #2: forced error"))
 	})
 
 	It("should generate a single INSERT INTO with suffix", func() {
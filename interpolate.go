@@ -0,0 +1,129 @@
+package sqlf
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// sqlInterpolatedHeader marks the interpolated SQL as unsafe to execute.
+	sqlInterpolatedHeader = "/* sqlf: interpolated for debugging, unsafe to execute */ "
+
+	// ErrInterpolationArgsMismatch is returned when the placeholders of the rendered SQL do not match its args.
+	ErrInterpolationArgsMismatch = errors.New("the placeholders of the interpolated SQL do not match its args")
+)
+
+// InterpolatedSQL is a SQL with its args inlined as literals, for logging and debugging. It starts with a comment
+// marking it as unsafe to execute.
+//
+// The literals are escaped according to the dialect, but it is not a replacement for binding args: NEVER execute
+// it, nor build other queries from it.
+type InterpolatedSQL string
+
+// DebugFormatter renders statements with their args inlined as literals (check `InterpolatedSQL`). The zero value
+// is ready to use.
+type DebugFormatter struct {
+	// Dialect defines how the literals are written. When nil, the dialect of the statement is used, falling back to
+	// the SQL standard.
+	Dialect Dialect
+
+	// Redact, when defined, is called for each arg (`idx` starts at 0) and its result is inlined instead. Ex: to
+	// hide passwords and personal data from the logs.
+	Redact func(idx int, arg interface{}) interface{}
+}
+
+// dialectStatement is implemented by the statements that have a dialect.
+type dialectStatement interface {
+	statementDialect() Dialect
+}
+
+// Format renders the `statement` with its args inlined as literals.
+//
+// The statement is rendered with `?` placeholders, whatever its placeholder format is, and each `?` is replaced by
+// the literal of its arg. `sql.NamedArg`s are inlined by their value.
+func (f *DebugFormatter) Format(statement FastSqlizer) (InterpolatedSQL, error) {
+	var sb interpolationWriter
+	args := make([]interface{}, 0)
+	// Rendering into a writer that already carries a context makes the statement behave as a nested one, so it
+	// does not wrap the writer into its placeholder format.
+	err := statement.ToSQLFast(&renderWriter{SQLWriter: &sb}, &args)
+	if err != nil {
+		return "", err
+	}
+
+	dialect := f.Dialect
+	if s, ok := statement.(dialectStatement); ok && dialect == nil {
+		dialect = s.statementDialect()
+	}
+	if dialect == nil {
+		dialect = standardDialect
+	}
+	return f.interpolate(sb.String(), args, dialect)
+}
+
+// interpolationWriter is the writer `Format` renders into. As `interpolate` outputs the `??` escapes as `?`, it
+// implements `questionUnescaper`.
+type interpolationWriter struct {
+	strings.Builder
+}
+
+// unescapesQuestionMarks implements `questionUnescaper`.
+func (w *interpolationWriter) unescapesQuestionMarks() {}
+
+// interpolate replaces each `?` in the SQL code of `query` by the literal of its arg. The `??` escapes are written
+// as `?`.
+func (f *DebugFormatter) interpolate(query string, args []interface{}, dialect Dialect) (InterpolatedSQL, error) {
+	var sb strings.Builder
+	lexer := sqlLexer{syntax: lexerSyntaxOf(dialect)}
+	sb.Grow(len(sqlInterpolatedHeader) + len(query) + 8*len(args))
+	sb.WriteString(sqlInterpolatedHeader)
+	argIdx, lastW := 0, 0
+	for i := 0; i < len(query); i++ {
+		if !lexer.next(query[i]) || query[i] != '?' {
+			continue
+		}
+		sb.WriteString(query[lastW:i])
+		if i+1 < len(query) && query[i+1] == '?' {
+			sb.WriteByte('?')
+			i++
+			lastW = i + 1
+			continue
+		}
+		if argIdx >= len(args) {
+			return "", fmt.Errorf("sqlf: %w: more placeholders than the %d args", ErrInterpolationArgsMismatch, len(args))
+		}
+		err := f.writeArg(&sb, dialect, argIdx, args[argIdx])
+		if err != nil {
+			return "", err
+		}
+		argIdx++
+		lastW = i + 1
+	}
+	if argIdx != len(args) {
+		return "", fmt.Errorf("sqlf: %w: %d placeholders and %d args", ErrInterpolationArgsMismatch, argIdx, len(args))
+	}
+	sb.WriteString(query[lastW:])
+	return InterpolatedSQL(sb.String()), nil
+}
+
+// writeArg writes the literal of the arg, redacted if the formatter has a `Redact` callback.
+func (f *DebugFormatter) writeArg(sb SQLWriter, dialect Dialect, idx int, arg interface{}) error {
+	if named, ok := arg.(sql.NamedArg); ok {
+		arg = named.Value
+	}
+	if f.Redact != nil {
+		arg = f.Redact(idx, arg)
+	}
+	err := dialect.WriteLiteral(sb, arg)
+	if err != nil {
+		return fmt.Errorf("sqlf: cannot interpolate the arg #%d (%T): %w", idx+1, arg, err)
+	}
+	return nil
+}
+
+// ToInterpolatedSQL renders the `statement` with its args inlined as literals, using a zero `DebugFormatter`.
+func ToInterpolatedSQL(statement FastSqlizer) (InterpolatedSQL, error) {
+	return new(DebugFormatter).Format(statement)
+}
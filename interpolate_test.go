@@ -0,0 +1,114 @@
+package sqlf_test
+
+import (
+	dbsql "database/sql"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/jamillosantos/sqlf"
+	"github.com/jamillosantos/sqlf/testingutils"
+)
+
+var _ = Describe("Interpolation", func() {
+	createdAt := time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC)
+
+	It("should inline the args of a select", func() {
+		sql, err := new(sqlf.SelectStatement).
+			Placeholder(sqlf.DollarPlaceholder).
+			From("users").
+			Where("name = ? AND active = ?", "O'Hara", true).
+			Where("created_at > ? AND deleted_at IS ?", createdAt, nil).
+			Where("score > ? AND level = ?", 1.5, 3).
+			Where("data ?? 'key' AND note <> 'why?'").
+			ToInterpolatedSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal(sqlf.InterpolatedSQL("/* sqlf: interpolated for debugging, unsafe to execute */ SELECT * FROM users WHERE name = 'O''Hara' AND active = TRUE AND created_at > '2021-02-03 04:05:06Z' AND deleted_at IS NULL AND score > 1.5 AND level = 3 AND data ? 'key' AND note <> 'why?'")))
+	})
+
+	DescribeTable("dialects",
+		func(dialect sqlf.Dialect, expected string) {
+			sql, err := new(sqlf.UpdateStatement).
+				Dialect(dialect).
+				Table("users").
+				Set("active", false, "bio", `back\slash`).
+				Where("avatar = ? AND id = ?", []byte{0xca, 0xfe}, 1).
+				ToInterpolatedSQL()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(sql)).To(Equal("/* sqlf: interpolated for debugging, unsafe to execute */ " + expected))
+		},
+		Entry("postgres", sqlf.Postgres, `UPDATE users SET active = FALSE, bio = 'back\slash' WHERE avatar = '\xcafe' AND id = 1`),
+		Entry("mysql", sqlf.MySQL, `UPDATE users SET active = FALSE, bio = 'back\\slash' WHERE avatar = X'cafe' AND id = 1`),
+		Entry("sqlserver", sqlf.SQLServer, `UPDATE users SET active = 0, bio = N'back\slash' WHERE avatar = 0xcafe AND id = 1`),
+	)
+
+	It("should follow the quoting rules of the dialect", func() {
+		sql, err := new(sqlf.SelectStatement).
+			Dialect(sqlf.MySQL).
+			From("users").
+			Where(`note <> 'it\'s ?' AND id = ?`, 1).
+			ToInterpolatedSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(sql)).To(Equal(`/* sqlf: interpolated for debugging, unsafe to execute */ SELECT * FROM users WHERE note <> 'it\'s ?' AND id = 1`))
+	})
+
+	It("should inline driver.Valuers and named args", func() {
+		sql, err := new(sqlf.DeleteStatement).
+			From("users").
+			Where("email = ? AND deleted_at < ?", dbsql.Named("email", "a@b.c"), dbsql.NullTime{}).
+			ToInterpolatedSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(sql)).To(Equal("/* sqlf: interpolated for debugging, unsafe to execute */ DELETE FROM users WHERE email = 'a@b.c' AND deleted_at < NULL"))
+	})
+
+	It("should inline the args of nested statements", func() {
+		sql, err := new(sqlf.InsertStatement).
+			Placeholder(sqlf.DollarPlaceholder).
+			Into("archive", "id").
+			Select(func(s sqlf.Select) {
+				s.Select("id").From("users").Where("active = ?", false)
+			}).
+			ToInterpolatedSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(sql)).To(Equal("/* sqlf: interpolated for debugging, unsafe to execute */ INSERT INTO archive (id) SELECT id FROM users WHERE active = FALSE"))
+	})
+
+	It("should redact the args", func() {
+		formatter := sqlf.DebugFormatter{
+			Dialect: sqlf.Postgres,
+			Redact: func(idx int, arg interface{}) interface{} {
+				if idx == 1 {
+					return "<redacted>"
+				}
+				return arg
+			},
+		}
+		sql, err := formatter.Format(new(sqlf.SelectStatement).From("users").Where("email = ? AND password = ?", "a@b.c", "secret"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(sql)).To(Equal("/* sqlf: interpolated for debugging, unsafe to execute */ SELECT * FROM users WHERE email = 'a@b.c' AND password = '<redacted>'"))
+	})
+
+	It("should fail interpolating an unsupported arg", func() {
+		_, err := new(sqlf.SelectStatement).From("users").Where("id = ?", struct{}{}).ToInterpolatedSQL()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(HavePrefix("sqlf: cannot interpolate the arg #1 (struct {})"))
+	})
+
+	It("should fail when the SQL has more placeholders than args", func() {
+		_, err := sqlf.ToInterpolatedSQL(&testingutils.MockerSqlizer{SQL: "id = ? AND name = ?", Args: []interface{}{1}})
+		Expect(errors.Is(err, sqlf.ErrInterpolationArgsMismatch)).To(BeTrue())
+	})
+
+	It("should fail when the SQL has more args than placeholders", func() {
+		_, err := sqlf.ToInterpolatedSQL(&testingutils.MockerSqlizer{SQL: "id = ?", Args: []interface{}{1, 2}})
+		Expect(err).To(MatchError("sqlf: the placeholders of the interpolated SQL do not match its args: 1 placeholders and 2 args"))
+	})
+
+	It("should return the error of the statement", func() {
+		_, err := new(sqlf.SelectStatement).From("users").Where("id = ?").ToInterpolatedSQL()
+		Expect(errors.Is(err, sqlf.ErrPlaceholderMismatch)).To(BeTrue())
+	})
+})
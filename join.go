@@ -46,14 +46,24 @@ func (join *JoinClause) Using(fields ...interface{}) Select {
 
 // ToSQLFast generates the SQL and returns it, alongside its params.
 func (join *JoinClause) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
+	err := checkRawSQL(sb, join.joinType)
+	if err != nil {
+		return err
+	}
 	sb.WriteString(join.joinType)
 	sb.Write(sqlSelectJoinClause)
-	sb.WriteString(join.table)
+	err = writeTable(sb, join.table)
+	if err != nil {
+		return err
+	}
 
 	// If `as` is not defined, don't append it.
 	if join.as != "" {
 		sb.Write(sqlSelectAsClause)
-		sb.WriteString(join.as)
+		err := writeAlias(sb, join.as)
+		if err != nil {
+			return err
+		}
 	}
 
 	// Supposely ON and USING cannot be used together. Let the user deal with it.
@@ -61,16 +71,10 @@ func (join *JoinClause) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
 	// ON added only if defined.
 	if len(join.on) > 0 {
 		sb.Write(sqlSelectJoinOnClause)
-		for idx, join := range join.on {
-			// By default criteria is joined by `AND` condition.
-			if idx > 0 {
-				sb.Write(sqlConditionAnd)
-			}
-
-			err := join.ToSQLFast(sb, args)
-			if err != nil {
-				return err
-			}
+		// By default criteria is joined by `AND` condition.
+		err := writeConditions(sb, args, "", "ON", join.on)
+		if err != nil {
+			return err
 		}
 	}
 
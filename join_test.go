@@ -79,7 +79,7 @@ var _ = Describe("JoinClause", func() {
 		err := join.ToSQLFast(sb, &args)
 		Expect(args).To(BeEmpty())
 		Expect(err).To(HaveOccurred())
-		Expect(err.Error()).To(Equal("forced error"))
+		Expect(err).To(MatchError("sqlf: ON #1: forced error"))
 	})
 
 	It("should generate a JOIN SQL with USING clause", func() {
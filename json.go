@@ -0,0 +1,277 @@
+package sqlf
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+var (
+	sqlJSONGet            = []byte(" -> ")
+	sqlJSONGetText        = []byte(" ->> ")
+	sqlJSONGetPath        = []byte(" #> ")
+	sqlJSONGetPathText    = []byte(" #>> ")
+	sqlJSONContains       = []byte(" @> ")
+	sqlJSONHasKey         = []byte(" ? ")
+	sqlJSONHasAnyKey      = []byte(" ?| ")
+	sqlJSONHasAllKeys     = []byte(" ?& ")
+	sqlJSONHasKeyEsc      = []byte(" ?? ")
+	sqlJSONHasAnyKeyEsc   = []byte(" ??| ")
+	sqlJSONHasAllKeysEsc  = []byte(" ??& ")
+	sqlJSONExtract        = []byte("JSON_EXTRACT(")
+	sqlJSONUnquoteExtract = []byte("JSON_UNQUOTE(JSON_EXTRACT(")
+	sqlJSONContainsFunc   = []byte("JSON_CONTAINS(")
+	sqlJSONContainsPath   = []byte("JSON_CONTAINS_PATH(")
+	sqlJSONPathOne        = []byte(", 'one'")
+	sqlJSONPathAll        = []byte(", 'all'")
+	jsonPathEscaper       = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+)
+
+// JSONOperators groups the JSON expressions and predicates of a database technology.
+//
+// Use `PostgresJSON` or `MySQLJSON` according with the database. Rendering fails with an `UnsupportedFeatureError`
+// when they do not match the dialect of the statement (check `FeaturePostgresJSON` and `FeatureMySQLJSON`):
+//
+//	sqlf.PostgresJSON.GetText("data", "address", "city") // data #>> ?
+//	sqlf.MySQLJSON.GetText("data", "address", "city")    // JSON_UNQUOTE(JSON_EXTRACT(data, ?))
+type JSONOperators interface {
+	// Get returns the JSON value of `column` at the given `path`.
+	Get(column interface{}, path ...string) FastSqlizer
+
+	// GetText returns the value of `column` at the given `path` as text.
+	GetText(column interface{}, path ...string) FastSqlizer
+
+	// Contains checks if the `column` JSON document contains `value`. `value` is encoded as JSON, unless it is a
+	// `string`, `[]byte` or `json.RawMessage`.
+	Contains(column interface{}, value interface{}) FastSqlizer
+
+	// HasKey checks if the `column` JSON object has the top level `key`.
+	HasKey(column interface{}, key string) FastSqlizer
+
+	// HasAnyKey checks if the `column` JSON object has any of the top level `keys`.
+	HasAnyKey(column interface{}, keys ...string) FastSqlizer
+
+	// HasAllKeys checks if the `column` JSON object has all the top level `keys`.
+	HasAllKeys(column interface{}, keys ...string) FastSqlizer
+}
+
+type postgresJSON struct{}
+
+type mysqlJSON struct{}
+
+var (
+	// PostgresJSON implements the JSON operators for Postgres (`->`, `->>`, `#>`, `#>>`, `@>`, `?`, `?|` and `?&`).
+	//
+	// The key existence operators are rendered escaped (`??`, `??|` and `??&`) when the placeholder format replaces
+	// the `?` (Ex: `DollarPlaceholder`), so it outputs them as `?`, `?|` and `?&` instead of replacing them by a
+	// placeholder. Otherwise, they are rendered as they are.
+	PostgresJSON JSONOperators = &postgresJSON{}
+
+	// MySQLJSON implements the JSON operators for MySQL (`JSON_EXTRACT`, `JSON_UNQUOTE`, `JSON_CONTAINS` and
+	// `JSON_CONTAINS_PATH`).
+	MySQLJSON JSONOperators = &mysqlJSON{}
+)
+
+// jsonOperation renders `<column> <operator> <value>`.
+type jsonOperation struct {
+	column   interface{}
+	operator []byte
+	// escaped, when defined, is the `operator` with its `?` escaped as `??`. It is used instead of the `operator`
+	// when the placeholder format replaces the `?`.
+	escaped []byte
+	value   interface{}
+	err     error
+}
+
+// ToSQLFast generates the SQL and returns it, alongside its params.
+func (op *jsonOperation) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
+	if op.err != nil {
+		return op.err
+	}
+	err := checkFeature(sb, FeaturePostgresJSON)
+	if err != nil {
+		return err
+	}
+	err = RenderInterfaceAsSQL(sb, args, op.column)
+	if err != nil {
+		return err
+	}
+	if op.escaped != nil && questionMarksEscaped(sb) {
+		sb.Write(op.escaped)
+	} else {
+		sb.Write(op.operator)
+	}
+	sb.Write(sqlPredicatePlaceholder)
+	*args = append(*args, op.value)
+	return nil
+}
+
+// jsonFunction renders `<prefix><column><middle>, ?, ?...)<suffix>`.
+type jsonFunction struct {
+	prefix []byte
+	column interface{}
+	middle []byte
+	values []interface{}
+	suffix []byte
+	err    error
+}
+
+// ToSQLFast generates the SQL and returns it, alongside its params.
+func (fnc *jsonFunction) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
+	if fnc.err != nil {
+		return fnc.err
+	}
+	err := checkFeature(sb, FeatureMySQLJSON)
+	if err != nil {
+		return err
+	}
+	sb.Write(fnc.prefix)
+	err = RenderInterfaceAsSQL(sb, args, fnc.column)
+	if err != nil {
+		return err
+	}
+	sb.Write(fnc.middle)
+	for _, value := range fnc.values {
+		sb.Write(sqlComma)
+		sb.Write(sqlPredicatePlaceholder)
+		*args = append(*args, value)
+	}
+	sb.Write(sqlBracketClose)
+	sb.Write(fnc.suffix)
+	return nil
+}
+
+// Get renders `<column> -> ?` for a single key, and `<column> #> ?` for a path.
+func (*postgresJSON) Get(column interface{}, path ...string) FastSqlizer {
+	if len(path) == 1 {
+		return &jsonOperation{column: column, operator: sqlJSONGet, value: path[0]}
+	}
+	return &jsonOperation{column: column, operator: sqlJSONGetPath, value: Array(path)}
+}
+
+// GetText renders `<column> ->> ?` for a single key, and `<column> #>> ?` for a path.
+func (*postgresJSON) GetText(column interface{}, path ...string) FastSqlizer {
+	if len(path) == 1 {
+		return &jsonOperation{column: column, operator: sqlJSONGetText, value: path[0]}
+	}
+	return &jsonOperation{column: column, operator: sqlJSONGetPathText, value: Array(path)}
+}
+
+// Contains renders `<column> @> ?`.
+func (*postgresJSON) Contains(column interface{}, value interface{}) FastSqlizer {
+	document, err := jsonDocument(value)
+	return &jsonOperation{column: column, operator: sqlJSONContains, value: document, err: err}
+}
+
+// HasKey renders `<column> ? ?` (`??` when escaped).
+func (*postgresJSON) HasKey(column interface{}, key string) FastSqlizer {
+	return &jsonOperation{column: column, operator: sqlJSONHasKey, escaped: sqlJSONHasKeyEsc, value: key}
+}
+
+// HasAnyKey renders `<column> ?| ?` (`??|` when escaped).
+func (*postgresJSON) HasAnyKey(column interface{}, keys ...string) FastSqlizer {
+	return &jsonOperation{column: column, operator: sqlJSONHasAnyKey, escaped: sqlJSONHasAnyKeyEsc, value: Array(keys)}
+}
+
+// HasAllKeys renders `<column> ?& ?` (`??&` when escaped).
+func (*postgresJSON) HasAllKeys(column interface{}, keys ...string) FastSqlizer {
+	return &jsonOperation{column: column, operator: sqlJSONHasAllKeys, escaped: sqlJSONHasAllKeysEsc, value: Array(keys)}
+}
+
+// Get renders `JSON_EXTRACT(<column>, ?)`.
+func (*mysqlJSON) Get(column interface{}, path ...string) FastSqlizer {
+	return &jsonFunction{prefix: sqlJSONExtract, column: column, values: []interface{}{MySQLJSONPath(path...)}}
+}
+
+// GetText renders `JSON_UNQUOTE(JSON_EXTRACT(<column>, ?))`.
+func (*mysqlJSON) GetText(column interface{}, path ...string) FastSqlizer {
+	return &jsonFunction{
+		prefix: sqlJSONUnquoteExtract,
+		column: column,
+		values: []interface{}{MySQLJSONPath(path...)},
+		suffix: sqlBracketClose,
+	}
+}
+
+// Contains renders `JSON_CONTAINS(<column>, ?)`.
+func (*mysqlJSON) Contains(column interface{}, value interface{}) FastSqlizer {
+	document, err := jsonDocument(value)
+	return &jsonFunction{prefix: sqlJSONContainsFunc, column: column, values: []interface{}{document}, err: err}
+}
+
+// HasKey renders `JSON_CONTAINS_PATH(<column>, 'one', ?)`.
+func (*mysqlJSON) HasKey(column interface{}, key string) FastSqlizer {
+	return &jsonFunction{
+		prefix: sqlJSONContainsPath,
+		column: column,
+		middle: sqlJSONPathOne,
+		values: []interface{}{MySQLJSONPath(key)},
+	}
+}
+
+// HasAnyKey renders `JSON_CONTAINS_PATH(<column>, 'one', ?, ?...)`.
+func (*mysqlJSON) HasAnyKey(column interface{}, keys ...string) FastSqlizer {
+	return &jsonFunction{prefix: sqlJSONContainsPath, column: column, middle: sqlJSONPathOne, values: mysqlJSONKeyPaths(keys)}
+}
+
+// HasAllKeys renders `JSON_CONTAINS_PATH(<column>, 'all', ?, ?...)`.
+func (*mysqlJSON) HasAllKeys(column interface{}, keys ...string) FastSqlizer {
+	return &jsonFunction{prefix: sqlJSONContainsPath, column: column, middle: sqlJSONPathAll, values: mysqlJSONKeyPaths(keys)}
+}
+
+// MySQLJSONPath builds a MySQL JSON path (`$."a"."b"[0]`) from its parts. Keys are always quoted, so they can
+// contain any character. Numeric parts are considered array indexes.
+func MySQLJSONPath(path ...string) string {
+	var sb strings.Builder
+	sb.WriteByte('$')
+	for _, part := range path {
+		if isJSONArrayIndex(part) {
+			sb.WriteByte('[')
+			sb.WriteString(part)
+			sb.WriteByte(']')
+			continue
+		}
+		sb.WriteString(`."`)
+		jsonPathEscaper.WriteString(&sb, part)
+		sb.WriteByte('"')
+	}
+	return sb.String()
+}
+
+// mysqlJSONKeyPaths returns a path for each top level key.
+func mysqlJSONKeyPaths(keys []string) []interface{} {
+	paths := make([]interface{}, len(keys))
+	for i, key := range keys {
+		paths[i] = MySQLJSONPath(key)
+	}
+	return paths
+}
+
+// isJSONArrayIndex returns true when the path part is only digits.
+func isJSONArrayIndex(part string) bool {
+	if part == "" {
+		return false
+	}
+	for i := 0; i < len(part); i++ {
+		if part[i] < '0' || part[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// jsonDocument returns the argument that represents `value` as a JSON document.
+func jsonDocument(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.RawMessage:
+		return string(v), nil
+	case []byte:
+		return string(v), nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,113 @@
+package sqlf_test
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/jamillosantos/sqlf"
+)
+
+var _ = Describe("JSON", func() {
+	render := func(sqlizer sqlf.FastSqlizer) (string, []interface{}, error) {
+		sb, args := new(strings.Builder), make([]interface{}, 0)
+		err := sqlizer.ToSQLFast(sb, &args)
+		return sb.String(), args, err
+	}
+
+	// argValues resolves any `driver.Valuer` in the args so arrays can be compared.
+	argValues := func(args []interface{}) []interface{} {
+		values := make([]interface{}, len(args))
+		for i, arg := range args {
+			values[i] = arg
+			if valuer, ok := arg.(driver.Valuer); ok {
+				values[i], _ = valuer.Value()
+			}
+		}
+		return values
+	}
+
+	DescribeTable("operators",
+		func(sqlizer sqlf.FastSqlizer, expectedSQL string, expectedArgs []interface{}) {
+			sql, args, err := render(sqlizer)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sql).To(Equal(expectedSQL))
+			Expect(argValues(args)).To(Equal(expectedArgs))
+		},
+		Entry("postgres Get", sqlf.PostgresJSON.Get("data", "address"), "data -> ?", []interface{}{"address"}),
+		Entry("postgres Get path", sqlf.PostgresJSON.Get("data", "address", "city"), "data #> ?", []interface{}{`{"address","city"}`}),
+		Entry("postgres GetText", sqlf.PostgresJSON.GetText("data", "name"), "data ->> ?", []interface{}{"name"}),
+		Entry("postgres GetText path", sqlf.PostgresJSON.GetText("data", "tags", "0"), "data #>> ?", []interface{}{`{"tags","0"}`}),
+		Entry("postgres Contains", sqlf.PostgresJSON.Contains("data", map[string]interface{}{"active": true}), "data @> ?", []interface{}{`{"active":true}`}),
+		Entry("postgres Contains raw", sqlf.PostgresJSON.Contains("data", `{"a":1}`), "data @> ?", []interface{}{`{"a":1}`}),
+		Entry("postgres HasKey", sqlf.PostgresJSON.HasKey("data", "email"), "data ? ?", []interface{}{"email"}),
+		Entry("postgres HasAnyKey", sqlf.PostgresJSON.HasAnyKey("data", "a", "b"), "data ?| ?", []interface{}{`{"a","b"}`}),
+		Entry("postgres HasAllKeys", sqlf.PostgresJSON.HasAllKeys("data", "a", "b"), "data ?& ?", []interface{}{`{"a","b"}`}),
+		Entry("mysql Get", sqlf.MySQLJSON.Get("data", "address", "city"), "JSON_EXTRACT(data, ?)", []interface{}{`$."address"."city"`}),
+		Entry("mysql GetText", sqlf.MySQLJSON.GetText("data", "tags", "0"), "JSON_UNQUOTE(JSON_EXTRACT(data, ?))", []interface{}{`$."tags"[0]`}),
+		Entry("mysql Contains", sqlf.MySQLJSON.Contains("data", []int{1, 2}), "JSON_CONTAINS(data, ?)", []interface{}{`[1,2]`}),
+		Entry("mysql HasKey", sqlf.MySQLJSON.HasKey("data", `we"ird`), "JSON_CONTAINS_PATH(data, 'one', ?)", []interface{}{`$."we\"ird"`}),
+		Entry("mysql HasAnyKey", sqlf.MySQLJSON.HasAnyKey("data", "a", "b"), "JSON_CONTAINS_PATH(data, 'one', ?, ?)", []interface{}{`$."a"`, `$."b"`}),
+		Entry("mysql HasAllKeys", sqlf.MySQLJSON.HasAllKeys("data", "a", "b"), "JSON_CONTAINS_PATH(data, 'all', ?, ?)", []interface{}{`$."a"`, `$."b"`}),
+	)
+
+	It("should fail encoding an invalid JSON document", func() {
+		_, _, err := render(sqlf.PostgresJSON.Contains("data", make(chan int)))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should fail using the operators of another dialect", func() {
+		_, _, err := new(sqlf.SelectStatement).
+			Dialect(sqlf.MySQL).
+			Select(sqlf.MySQLJSON.GetText("data", "name")).
+			From("users").
+			WhereCriteria(sqlf.PostgresJSON.HasKey("data", "email")).
+			ToSQL()
+		Expect(errors.Is(err, sqlf.ErrUnsupportedFeature)).To(BeTrue())
+		Expect(err).To(MatchError(`sqlf: SELECT WHERE #1: the mysql dialect does not support the Postgres JSON operators`))
+	})
+
+	It("should keep the key existence operators with the dollar placeholder", func() {
+		sql, args, err := new(sqlf.SelectStatement).
+			Placeholder(sqlf.DollarPlaceholder).
+			Select(sqlf.PostgresJSON.GetText("data", "name")).
+			From("users").
+			Where("account_id = ?", 7).
+			WhereCriteria(
+				sqlf.PostgresJSON.HasKey("data", "email"),
+				sqlf.PostgresJSON.HasAnyKey("data", "a", "b"),
+				sqlf.PostgresJSON.HasAllKeys("data", "c"),
+			).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(HaveLen(5))
+		Expect(sql).To(Equal("SELECT data ->> $1 FROM users WHERE account_id = $2 AND data ? $3 AND data ?| $4 AND data ?& $5"))
+	})
+
+	It("should not escape the key existence operators with the question placeholder", func() {
+		sql, args, err := new(sqlf.SelectStatement).
+			From("users").
+			WhereCriteria(
+				sqlf.PostgresJSON.HasKey("data", "email"),
+				sqlf.PostgresJSON.HasAnyKey("data", "a", "b"),
+			).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(HaveLen(2))
+		Expect(sql).To(Equal("SELECT * FROM users WHERE data ? ? AND data ?| ?"))
+	})
+
+	It("should keep the key existence operators when interpolated", func() {
+		sql, err := new(sqlf.SelectStatement).
+			Dialect(sqlf.Postgres).
+			From("users").
+			WhereCriteria(sqlf.PostgresJSON.HasKey("data", "email")).
+			ToInterpolatedSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(sql)).To(Equal("/* sqlf: interpolated for debugging, unsafe to execute */ SELECT * FROM users WHERE data ? 'email'"))
+	})
+})
@@ -0,0 +1,247 @@
+package sqlf
+
+// lexerState is where the `sqlLexer` is in the SQL.
+type lexerState uint8
+
+const (
+	// lexCode is plain SQL, where placeholders and named parameters are found.
+	lexCode lexerState = iota
+	// lexSingleQuote is a string literal: 'text'.
+	lexSingleQuote
+	// lexDoubleQuote is a quoted identifier: "name".
+	lexDoubleQuote
+	// lexBacktick is a MySQL quoted identifier: `name`.
+	lexBacktick
+	// lexLineComment is a comment up to the end of the line: -- text.
+	lexLineComment
+	// lexBlockComment is a block comment: /* text */.
+	lexBlockComment
+	// lexDollarTag is reading what may be the opening tag of a dollar quoted string: $tag$.
+	lexDollarTag
+	// lexDollarBody is the body of a dollar quoted string: $tag$ text $tag$.
+	lexDollarBody
+	// lexBracket is a SQL Server quoted identifier: [name]. Only with `syntaxBrackets`.
+	lexBracket
+	// lexBracketEnd is right after the `]` of a quoted identifier, which may be the start of the `]]` escape.
+	lexBracketEnd
+)
+
+// lexerSyntax are the flags of the dialect specific rules of the `sqlLexer`.
+type lexerSyntax uint8
+
+const (
+	// syntaxBrackets makes `[` open a quoted identifier, closed by `]` and escaping it as `]]` (SQL Server).
+	// Otherwise, `[` is code, as in the Postgres `ARRAY[?]`.
+	syntaxBrackets lexerSyntax = 1 << iota
+	// syntaxBackslashes makes `\` escape the byte that follows it in string literals (MySQL). Otherwise, a `\`
+	// is a regular byte, as in the SQL standard.
+	syntaxBackslashes
+)
+
+// sqlLexer tells apart the SQL code from string literals, quoted identifiers, dollar quoted strings and comments.
+// It is fed one byte at a time, so it keeps working when the SQL is written in many pieces.
+type sqlLexer struct {
+	// syntax are the dialect specific rules. Check `lexerSyntaxOf`.
+	syntax lexerSyntax
+	state  lexerState
+	// prev is the previous byte, used to detect the `--`, `/*` and `*/` delimiters and the `$` of identifiers.
+	prev byte
+	// tag is the dollar quote tag being read or, in the body, the tag that closes it (including both `$`).
+	tag []byte
+	// match is how many bytes of the closing `tag` were matched.
+	match int
+	// escaped is true when the previous byte of the string literal was a `\` escape. Check `syntaxBackslashes`.
+	escaped bool
+}
+
+// next advances the lexer by `c`. It returns true when `c` is SQL code, where a `?` is a placeholder.
+func (l *sqlLexer) next(c byte) bool {
+	prev := l.prev
+	l.prev = c
+	switch l.state {
+	case lexSingleQuote, lexDoubleQuote:
+		switch {
+		case l.escaped:
+			l.escaped = false
+		case c == '\\' && l.syntax&syntaxBackslashes != 0:
+			l.escaped = true
+		case c == '\'' && l.state == lexSingleQuote, c == '"' && l.state == lexDoubleQuote:
+			l.state = lexCode
+		}
+		return false
+	case lexBracket:
+		if c == ']' {
+			l.state = lexBracketEnd
+		}
+		return false
+	case lexBracketEnd:
+		if c == ']' {
+			// Escaped `]]`, still inside of the identifier.
+			l.state = lexBracket
+			return false
+		}
+		// The identifier was closed by the previous `]`, so `c` is code.
+		l.state = lexCode
+	case lexBacktick:
+		if c == '`' {
+			l.state = lexCode
+		}
+		return false
+	case lexLineComment:
+		if c == '\n' {
+			l.state = lexCode
+		}
+		return false
+	case lexBlockComment:
+		if prev == '*' && c == '/' {
+			l.state = lexCode
+			l.prev = 0
+		}
+		return false
+	case lexDollarTag:
+		switch {
+		case c == '$':
+			l.tag = append(l.tag, c)
+			l.state = lexDollarBody
+			l.match = 0
+			return false
+		case isTagByte(c, len(l.tag) == 1):
+			l.tag = append(l.tag, c)
+			return false
+		}
+		// Not a dollar quote (Ex: `$1`), so `c` is code.
+		l.state = lexCode
+	case lexDollarBody:
+		if c == l.tag[l.match] {
+			l.match++
+		} else if c == l.tag[0] {
+			l.match = 1
+		} else {
+			l.match = 0
+		}
+		if l.match == len(l.tag) {
+			l.state = lexCode
+			l.prev = 0
+		}
+		return false
+	}
+
+	switch c {
+	case '\'':
+		l.state = lexSingleQuote
+	case '"':
+		l.state = lexDoubleQuote
+	case '`':
+		l.state = lexBacktick
+	case '[':
+		if l.syntax&syntaxBrackets == 0 {
+			return true
+		}
+		l.state = lexBracket
+	case '$':
+		if isTagByte(prev, false) || prev == '$' {
+			// Part of an identifier (Ex: `a$b`), where the `$` cannot open a dollar quote.
+			return true
+		}
+		l.state = lexDollarTag
+		l.tag = append(l.tag[:0], c)
+	case '-':
+		if prev != '-' {
+			return true
+		}
+		l.state = lexLineComment
+	case '*':
+		if prev != '/' {
+			return true
+		}
+		l.state = lexBlockComment
+		l.prev = 0
+	default:
+		return true
+	}
+	return false
+}
+
+// lexerSpecial are the bytes that may change the state of the `sqlLexer` from `lexCode`, or be a placeholder.
+var lexerSpecial = [256]bool{'?': true, '\'': true, '"': true, '`': true, '[': true, '$': true, '-': true, '*': true}
+
+// indexString advances the lexer through `s` up to its first `?` in the SQL code, and returns the index of it. It
+// returns -1, having advanced through all of `s`, when there is none.
+//
+// The runs of bytes that cannot change the state of the lexer are skipped at once (check `lexerSpecial`).
+func (l *sqlLexer) indexString(s string) int {
+	for i := 0; i < len(s); i++ {
+		if l.state == lexCode {
+			start := i
+			for i < len(s) && !lexerSpecial[s[i]] {
+				i++
+			}
+			if i > start {
+				l.prev = s[i-1]
+			}
+			if i == len(s) {
+				return -1
+			}
+		}
+		if l.next(s[i]) && s[i] == '?' {
+			return i
+		}
+	}
+	return -1
+}
+
+// indexBytes is the `indexString` for a `[]byte`.
+func (l *sqlLexer) indexBytes(p []byte) int {
+	for i := 0; i < len(p); i++ {
+		if l.state == lexCode {
+			start := i
+			for i < len(p) && !lexerSpecial[p[i]] {
+				i++
+			}
+			if i > start {
+				l.prev = p[i-1]
+			}
+			if i == len(p) {
+				return -1
+			}
+		}
+		if l.next(p[i]) && p[i] == '?' {
+			return i
+		}
+	}
+	return -1
+}
+
+// reset makes the lexer ready for a new SQL, with the default syntax.
+func (l *sqlLexer) reset() {
+	l.syntax = 0
+	l.state = lexCode
+	l.prev = 0
+	l.tag = l.tag[:0]
+	l.match = 0
+	l.escaped = false
+}
+
+// lexerSyntaxOf returns the lexer rules of the dialect `d` (unwrapping the ones created by `WithRegistry`). Dialects
+// not created by this package use the default rules.
+func lexerSyntaxOf(d Dialect) lexerSyntax {
+	if registry, ok := d.(*registryDialect); ok {
+		d = registry.Dialect
+	}
+	if impl, ok := d.(*dialect); ok {
+		return impl.syntax
+	}
+	return 0
+}
+
+// isTagByte reports whether `c` can be part of a dollar quote tag. The tag follows the identifier rules, so it
+// cannot start with a digit (which makes `$1` a parameter).
+func isTagByte(c byte, first bool) bool {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '_', c >= 0x80:
+		return true
+	case !first && c >= '0' && c <= '9':
+		return true
+	}
+	return false
+}
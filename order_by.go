@@ -44,6 +44,11 @@ func (orderBy *OrderByClause) Desc(fields ...interface{}) OrderBy {
 // ToSQLFast generates the SQL and returns it, alongside its params.
 func (orderBy *OrderByClause) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
 	sb.Write(sqlSelectOrderByClause)
+	return orderBy.writeFields(sb, args)
+}
+
+// writeFields writes the fields of the clause, without the ORDER BY keyword.
+func (orderBy *OrderByClause) writeFields(sb SQLWriter, args *[]interface{}) error {
 	for idx, field := range orderBy.fields {
 		if idx > 0 {
 			sb.Write(sqlComma)
@@ -1,6 +1,7 @@
 package sqlf
 
 import (
+	"database/sql"
 	"strconv"
 	"strings"
 	"sync"
@@ -18,34 +19,117 @@ type PlaceholderFormatFactory interface {
 	Wrap(writer SQLWriter) SQLWriter
 
 	// Put aims to return the wrapper to a pool. Of course, it depends on the factory implementation.
+	//
+	// Statements call it when they finish rendering (even when it fails), so the wrapper must not be used
+	// afterwards.
 	Put(writer SQLWriter)
 }
 
+// PlaceholderFlusher is implemented by the writers (created by `PlaceholderFormatFactory.Wrap`) that hold back
+// the end of what was written. Ex: a trailing `?`, that may be the start of a `??` escape split across two writes.
+//
+// The writer is flushed when the statement that wrapped it finishes rendering.
+type PlaceholderFlusher interface {
+	// Flush writes what was held back.
+	Flush() error
+}
+
+// PlaceholderArgsFormatter is implemented by the `PlaceholderFormatFactory`s (or the writers they create) that also
+// need to change the args bound to the placeholders. Ex: the named formats, that bind each argument as a
+// `sql.NamedArg`. When both implement it, the writer formats the args.
+//
+// The args are formatted when the statement that wrapped the writer finishes rendering.
+type PlaceholderArgsFormatter interface {
+	// FormatArgs replaces, in place, the `args` bound to the placeholders written through the wrapper. The first
+	// arg is bound to the first placeholder.
+	FormatArgs(args []interface{})
+}
+
+// questionUnescaper is implemented by the writers that output the `??` escape as `?`. Any other writer outputs
+// the SQL as it is, so the `?` that are not placeholders (Ex: the jsonb `?` operator) must not be escaped for them.
+type questionUnescaper interface {
+	unescapesQuestionMarks()
+}
+
+// questionMarksEscaped reports whether the `?` that are not placeholders must be escaped as `??` when written to
+// `sb`.
+func questionMarksEscaped(sb SQLWriter) bool {
+	_, ok := unwrapRenderWriter(sb).(questionUnescaper)
+	return ok
+}
+
 type questionPlaceholderFactory struct{}
 
-type dollarPlaceholderFactory struct {
-	pool sync.Pool
+// numberedPlaceholderFactory creates writers that replace each `?` by `<prefix><n>`, where n is the index of the
+// placeholder (starting at 1).
+type numberedPlaceholderFactory struct {
+	pool   sync.Pool
+	prefix []byte
+	// name, when defined, binds each argument as a `sql.NamedArg` called `<name><n>`. It must match the name
+	// part of the prefix.
+	name string
+	// sigil is the part of the prefix before the name (Ex: `@`), which precedes the named parameters when the
+	// factory is a named format.
+	sigil []byte
 }
 
-type dollarPlaceholder struct {
+type numberedPlaceholder struct {
+	renderMark
 	writer           SQLWriter
+	prefix           []byte
 	placeholderCount int
+	// names maps the named parameters already written to their placeholder number.
+	names map[string]int
+	// name and sigil are the ones of the factory, when it is a named format. Check `numberedPlaceholderFactory`.
+	name  string
+	sigil []byte
+	// argNames are the names of the placeholders written by a named format, in order: the named parameter, or
+	// empty for a `?`.
+	argNames []string
+	// lexer tells the placeholders apart from the `?` inside of literals, quoted identifiers and comments.
+	lexer sqlLexer
+	// pending is true when a `?` was held at the end of the last write. Check `Write`.
+	pending bool
 }
 
 var (
 	QuestionPlaceholder = &questionPlaceholderFactory{}
-	DollarPlaceholder   = &dollarPlaceholderFactory{
+
+	// DollarPlaceholder replaces `?` by `$1`, `$2`, ... used by Postgres.
+	DollarPlaceholder = newNumberedPlaceholderFactory("$", "")
+
+	// ColonPlaceholder replaces `?` by `:1`, `:2`, ... used by Oracle.
+	ColonPlaceholder = newNumberedPlaceholderFactory(":", "")
+
+	// AtPPlaceholder replaces `?` by `@p1`, `@p2`, ... used by SQL Server.
+	AtPPlaceholder = newNumberedPlaceholderFactory("@p", "")
+
+	// NamedColonPlaceholder renders the named parameters (check `Select.Bind`) by their names, as `:name`, and
+	// replaces each `?` by `:p<n>`, where n is the index of the placeholder. Each argument is bound as a
+	// `sql.NamedArg` of the same name. Arguments that already are `sql.NamedArg`s are renamed. The `p<n>` names
+	// are reserved for the `?`.
+	NamedColonPlaceholder = newNumberedPlaceholderFactory(":p", "p")
+
+	// NamedAtPlaceholder renders the named parameters by their names, as `@name`, and replaces each `?` by
+	// `@p<n>`. Check `NamedColonPlaceholder`.
+	NamedAtPlaceholder = newNumberedPlaceholderFactory("@p", "p")
+)
+
+func newNumberedPlaceholderFactory(prefix, name string) *numberedPlaceholderFactory {
+	return &numberedPlaceholderFactory{
 		pool: sync.Pool{
 			New: func() interface{} {
-				return &dollarPlaceholder{}
+				return &numberedPlaceholder{}
 			},
 		},
+		prefix: []byte(prefix),
+		name:   name,
+		sigil:  []byte(prefix[:len(prefix)-len(name)]),
 	}
-)
+}
 
 var (
 	questionSign = byte('?')
-	dollarSign   = byte('$')
 )
 
 // Wrap returns the given sqlWriter without wrapping it to anything. That is so because the default placeholder is
@@ -57,112 +141,247 @@ func (q *questionPlaceholderFactory) Wrap(sqlWriter SQLWriter) SQLWriter {
 // Put just does nothing as `?` placeholder is the default.
 func (q *questionPlaceholderFactory) Put(SQLWriter) {}
 
-// Wrap wraps the given `sqlWriter` into a `dollarPlaceholder` that will replace any found `?` by a `$1` where 1 is
-// the index of the placeholder. Each `?` will be considered a new placeholder.
+// Wrap wraps the given `sqlWriter` into a `numberedPlaceholder` that will replace any found `?` by the prefix
+// followed by the index of the placeholder (Ex: `$1`). Each `?` in the SQL code will be considered a new
+// placeholder, the ones inside of string literals, quoted identifiers, dollar quoted strings and comments are kept.
+// The statement makes it follow the quoting rules of its dialect: the `[name]` identifiers of SQL Server and the
+// `\` escapes of the MySQL string literals.
 //
-// To add `?` to the SQL query, you should double `??`. This way, the placeholder will escape and output `?`.
-func (q *dollarPlaceholderFactory) Wrap(sqlWriter SQLWriter) SQLWriter {
-	writer := q.pool.Get().(*dollarPlaceholder)
+// To add `?` to the SQL code (Ex: the jsonb `?` operator), you should double `??`. This way, the placeholder will
+// escape and output `?`.
+func (q *numberedPlaceholderFactory) Wrap(sqlWriter SQLWriter) SQLWriter {
+	writer := q.pool.Get().(*numberedPlaceholder)
 	writer.writer = sqlWriter
+	writer.prefix = q.prefix
+	if q.name != "" {
+		writer.name = q.name
+		writer.sigil = q.sigil
+	}
 	return writer
 }
 
-func (q *dollarPlaceholderFactory) Put(sqlWriter SQLWriter) {
-	dollar, ok := sqlWriter.(*dollarPlaceholder)
+// Put resets the writer and returns it to the pool.
+func (q *numberedPlaceholderFactory) Put(sqlWriter SQLWriter) {
+	numbered, ok := sqlWriter.(*numberedPlaceholder)
 	if !ok {
 		return
 	}
 
-	dollar.placeholderCount = 0
-	dollar.writer = nil
+	numbered.placeholderCount = 0
+	for name := range numbered.names {
+		delete(numbered.names, name)
+	}
+	numbered.name, numbered.sigil, numbered.argNames = "", nil, numbered.argNames[:0]
+	numbered.lexer.reset()
+	numbered.pending = false
+	numbered.renderMark = renderMark{}
+	numbered.writer = nil
+	q.pool.Put(numbered)
 }
 
-func (dp *dollarPlaceholder) WriteByte(p byte) (err error) {
-	return dp.writer.WriteByte(p)
+// FormatArgs binds each argument as a `sql.NamedArg`, when the factory is a named format.
+func (q *numberedPlaceholderFactory) FormatArgs(args []interface{}) {
+	if q.name == "" {
+		return
+	}
+	for idx, arg := range args {
+		if named, ok := arg.(sql.NamedArg); ok {
+			arg = named.Value
+		}
+		args[idx] = sql.Named(q.name+strconv.Itoa(idx+1), arg)
+	}
 }
 
-func (dp *dollarPlaceholder) Write(p []byte) (n int, err error) {
-	lastW := 0
-	for i := 0; i < len(p); i++ {
-		isInterrogation := p[i] == '?'
-		if !isInterrogation {
-			continue
+// FormatArgs binds each argument as a `sql.NamedArg`, when the writer is of a named format, named by the parameter
+// of its placeholder. Check `NamedColonPlaceholder`.
+func (dp *numberedPlaceholder) FormatArgs(args []interface{}) {
+	if dp.name == "" {
+		return
+	}
+	for idx, arg := range args {
+		if named, ok := arg.(sql.NamedArg); ok {
+			arg = named.Value
 		}
+		name := dp.name + strconv.Itoa(idx+1)
+		if idx < len(dp.argNames) && dp.argNames[idx] != "" {
+			name = dp.argNames[idx]
+		}
+		args[idx] = sql.Named(name, arg)
+	}
+}
 
-		if isInterrogation && len(p) > i+1 && p[i+1] == '?' {
-			// Write to the original writer and skip a ?
-			dp.writer.Write(p[lastW:i])
-			i++
-			lastW = i
-			continue
+// unescapesQuestionMarks implements `questionUnescaper`.
+func (dp *numberedPlaceholder) unescapesQuestionMarks() {}
+
+// WriteByte writes `c`, replacing it by a placeholder when it is a `?` in the SQL code.
+func (dp *numberedPlaceholder) WriteByte(c byte) error {
+	if dp.pending {
+		dp.pending = false
+		if c == '?' {
+			// Escaped `??`.
+			return dp.writer.WriteByte(c)
 		}
+		err := dp.writePlaceholder()
+		if err != nil {
+			return err
+		}
+	}
+	if dp.lexer.next(c) && c == '?' {
+		dp.pending = true
+		return nil
+	}
+	return dp.writer.WriteByte(c)
+}
 
-		if isInterrogation {
-			dp.placeholderCount++
-			_, err = dp.writer.Write(p[lastW:i])
-			if err != nil {
-				return 0, err // Well, this is not true. But it will do for now.
-			}
-			err = dp.writer.WriteByte(dollarSign)
-			if err != nil {
-				return 0, err // Well, this is not true. But it will do for now.
-			}
-			_, err = dp.writer.WriteString(strconv.Itoa(dp.placeholderCount))
+// Write writes `p`, replacing each `?` in the SQL code by a placeholder.
+//
+// String literals, quoted identifiers, dollar quoted strings and comments are written as they are. A `??` in the
+// SQL code is an escape that outputs `?`. As a `?` at the end of `p` may be the start of a `??` split across two
+// writes, it is held until the next write (or `Flush`).
+func (dp *numberedPlaceholder) Write(p []byte) (int, error) {
+	lastW, i := 0, 0
+	if dp.pending && len(p) > 0 {
+		dp.pending = false
+		if p[0] == '?' {
+			// Escaped `??`, the first `?` was held. So, this one is written.
+			i = 1
+		} else if err := dp.writePlaceholder(); err != nil {
+			return 0, err
+		}
+	}
+	for i < len(p) {
+		idx := dp.lexer.indexBytes(p[i:])
+		if idx < 0 {
+			break
+		}
+		idx += i
+		_, err := dp.writer.Write(p[lastW:idx])
+		if err != nil {
+			return lastW, err
+		}
+		lastW, i = idx+1, idx+1
+		switch {
+		case i == len(p):
+			dp.pending = true
+		case p[i] == '?':
+			// Escaped `??`, the second `?` is written.
+			i++
+		default:
+			err = dp.writePlaceholder()
 			if err != nil {
-				return 0, err // Well, this is not true. But it will do for now.
+				return lastW, err
 			}
-			lastW = i + 1
 		}
 	}
-
-	// If there is something in the buffer to be written.
-	if lastW != len(p) {
-		dp.writer.Write(p[lastW:])
+	if lastW < len(p) {
+		_, err := dp.writer.Write(p[lastW:])
+		if err != nil {
+			return lastW, err
+		}
 	}
 	return len(p), nil
 }
 
-func (dp *dollarPlaceholder) WriteString(s string) (n int, err error) {
-	lastW := 0
-	for i := 0; i < len(s); i++ {
-		isInterrogation := s[i] == '?'
-		if !isInterrogation {
-			continue
+// WriteString writes `s`, replacing each `?` in the SQL code by a placeholder. Check `Write`.
+func (dp *numberedPlaceholder) WriteString(s string) (int, error) {
+	lastW, i := 0, 0
+	if dp.pending && len(s) > 0 {
+		dp.pending = false
+		if s[0] == '?' {
+			// Escaped `??`, the first `?` was held. So, this one is written.
+			i = 1
+		} else if err := dp.writePlaceholder(); err != nil {
+			return 0, err
 		}
-
-		if isInterrogation && len(s) > i+1 && s[i+1] == '?' {
-			// Write to the original writer and skip a ?
-			dp.writer.WriteString(s[lastW:i])
-			i++
-			lastW = i
-			continue
+	}
+	for i < len(s) {
+		idx := dp.lexer.indexString(s[i:])
+		if idx < 0 {
+			break
 		}
-
-		if isInterrogation {
-			dp.placeholderCount++
-			_, err = dp.writer.WriteString(s[lastW:i])
-			if err != nil {
-				return 0, err // Well, this is not true. But it will do for now.
-			}
-			err = dp.writer.WriteByte(dollarSign)
-			if err != nil {
-				return 0, err // Well, this is not true. But it will do for now.
-			}
-			_, err = dp.writer.WriteString(strconv.Itoa(dp.placeholderCount))
+		idx += i
+		_, err := dp.writer.WriteString(s[lastW:idx])
+		if err != nil {
+			return lastW, err
+		}
+		lastW, i = idx+1, idx+1
+		switch {
+		case i == len(s):
+			dp.pending = true
+		case s[i] == '?':
+			// Escaped `??`, the second `?` is written.
+			i++
+		default:
+			err = dp.writePlaceholder()
 			if err != nil {
-				return 0, err // Well, this is not true. But it will do for now.
+				return lastW, err
 			}
-			lastW = i + 1
 		}
 	}
-
-	// If there is something in the buffer to be written.
-	if lastW != len(s) {
-		dp.writer.WriteString(s[lastW:])
+	if lastW < len(s) {
+		_, err := dp.writer.WriteString(s[lastW:])
+		if err != nil {
+			return lastW, err
+		}
 	}
 	return len(s), nil
 }
 
-func (dp *dollarPlaceholder) String() string {
+// Flush writes the placeholder of a `?` held at the end of the last write.
+func (dp *numberedPlaceholder) Flush() error {
+	if !dp.pending {
+		return nil
+	}
+	dp.pending = false
+	return dp.writePlaceholder()
+}
+
+// writePlaceholder writes the next placeholder.
+func (dp *numberedPlaceholder) writePlaceholder() error {
+	dp.placeholderCount++
+	if dp.name != "" {
+		dp.argNames = append(dp.argNames, "")
+	}
+	return dp.writeNumber(dp.placeholderCount)
+}
+
+// writeNumber writes the placeholder `<prefix><number>`.
+func (dp *numberedPlaceholder) writeNumber(number int) error {
+	_, err := dp.writer.Write(dp.prefix)
+	if err != nil {
+		return err
+	}
+	_, err = dp.writer.WriteString(strconv.Itoa(number))
+	return err
+}
+
+// WriteNamed writes the placeholder of the named parameter `name`. A name that was already written reuses its
+// number, so `:id AND :id` renders `$1 AND $1`. The named formats write the name instead (Ex: `@id`).
+func (dp *numberedPlaceholder) WriteNamed(name string) (reused bool) {
+	dp.Flush()
+	number, reused := dp.names[name]
+	if !reused {
+		dp.placeholderCount++
+		number = dp.placeholderCount
+		if dp.names == nil {
+			dp.names = make(map[string]int)
+		}
+		dp.names[name] = number
+		if dp.name != "" {
+			dp.argNames = append(dp.argNames, name)
+		}
+	}
+	if dp.name != "" {
+		dp.writer.Write(dp.sigil)
+		dp.writer.WriteString(name)
+		return reused
+	}
+	dp.writeNumber(number)
+	return reused
+}
+
+// String flushes the writer and returns the SQL written so far.
+func (dp *numberedPlaceholder) String() string {
+	dp.Flush()
 	return dp.writer.String()
 }
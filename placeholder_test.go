@@ -1,10 +1,13 @@
 package sqlf_test
 
 import (
+	dbsql "database/sql"
 	"strings"
+	"sync"
 
 	"github.com/jamillosantos/sqlf"
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 )
 
@@ -98,4 +101,173 @@ var _ = Describe("Placeholder", func() {
 			})
 		})
 	})
+
+	DescribeTable("numbered formats",
+		func(format sqlf.PlaceholderFormatFactory, expected string) {
+			sb := new(strings.Builder)
+			ph := format.Wrap(sb)
+			_, err := ph.WriteString("SELECT * FROM users WHERE account_id = ? AND data ?? 'key'")
+			Expect(err).ToNot(HaveOccurred())
+			_, err = ph.Write([]byte(" AND name LIKE ?"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ph.String()).To(Equal(expected))
+		},
+		Entry("colon", sqlf.ColonPlaceholder, "SELECT * FROM users WHERE account_id = :1 AND data ? 'key' AND name LIKE :2"),
+		Entry("at p", sqlf.AtPPlaceholder, "SELECT * FROM users WHERE account_id = @p1 AND data ? 'key' AND name LIKE @p2"),
+		Entry("named colon", sqlf.NamedColonPlaceholder, "SELECT * FROM users WHERE account_id = :p1 AND data ? 'key' AND name LIKE :p2"),
+		Entry("named at", sqlf.NamedAtPlaceholder, "SELECT * FROM users WHERE account_id = @p1 AND data ? 'key' AND name LIKE @p2"),
+	)
+
+	DescribeTable("should only replace the placeholders of the SQL code",
+		func(parts []string, expected string) {
+			ph := sqlf.DollarPlaceholder.Wrap(new(strings.Builder))
+			for _, part := range parts {
+				_, err := ph.WriteString(part)
+				Expect(err).ToNot(HaveOccurred())
+			}
+			Expect(ph.String()).To(Equal(expected))
+		},
+		Entry("string literal", []string{"a = 'what?' AND b = ?"}, "a = 'what?' AND b = $1"),
+		Entry("escaped quote", []string{"a = 'it''s ?' AND b = ?"}, "a = 'it''s ?' AND b = $1"),
+		Entry("quoted identifier", []string{`"why?" = ? AND `, "`how?` = ?"}, `"why?" = $1 AND `+"`how?` = $2"),
+		Entry("dollar quoted string", []string{"a = $$who?$$ AND b = $fn$ ? $x$ ? $fn$ AND c = ?"}, "a = $$who?$$ AND b = $fn$ ? $x$ ? $fn$ AND c = $1"),
+		Entry("line comment", []string{"a = ? -- why?\n AND b = ?"}, "a = $1 -- why?\n AND b = $2"),
+		Entry("block comment", []string{"a = ? /* why? */ AND b = ?"}, "a = $1 /* why? */ AND b = $2"),
+		Entry("literal split across writes", []string{"a = 'wh", "at?' AND b = ", "?"}, "a = 'what?' AND b = $1"),
+		Entry("comment start split across writes", []string{"a = ? -", "- why?\n AND b = ?"}, "a = $1 -- why?\n AND b = $2"),
+		Entry("escape split across writes", []string{"data ?", "? 'key' AND id = ?"}, "data ? 'key' AND id = $1"),
+		Entry("placeholder at the end of a write", []string{"id IN (?", ", ?", ")"}, "id IN ($1, $2)"),
+		Entry("not a dollar quote", []string{"a = ? AND b = $1"}, "a = $1 AND b = $1"),
+		Entry("dollar inside of identifiers", []string{"a$b$ = ? AND c$ = ? AND $$x?$$ = ?"}, "a$b$ = $1 AND c$ = $2 AND $$x?$$ = $3"),
+		Entry("dollar inside of an identifier split across writes", []string{"a$", "b$ = ?"}, "a$b$ = $1"),
+		Entry("star right after a block comment", []string{"a = ? /* x */* ?"}, "a = $1 /* x */* $2"),
+		Entry("comment start split across plain writes", []string{"a = ? /", "* why? */ AND b = ?"}, "a = $1 /* why? */ AND b = $2"),
+	)
+
+	It("should follow the quoting rules of the dialect", func() {
+		sql, args, err := new(sqlf.SelectStatement).
+			Dialect(sqlf.SQLServer).
+			From("users").
+			Where("[why?] = ? AND [a]]?] = ?", 1, 2).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("SELECT * FROM users WHERE [why?] = @p1 AND [a]]?] = @p2"))
+		Expect(args).To(Equal([]interface{}{1, 2}))
+
+		sql, args, err = new(sqlf.SelectStatement).
+			Dialect(sqlf.MySQL).
+			From("users").
+			Where(`note <> 'it\'s ?' AND name = :name AND id IN (?)`, []int{1, 2}).
+			Bind(map[string]interface{}{"name": "john"}).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal(`SELECT * FROM users WHERE note <> 'it\'s ?' AND name = ? AND id IN (?, ?)`))
+		Expect(args).To(Equal([]interface{}{"john", 1, 2}))
+
+		sql, _, err = new(sqlf.SelectStatement).
+			Dialect(sqlf.Postgres).
+			From("users").
+			Where(`tags && ARRAY[?] AND path <> 'C:\'`, "a").
+			Where("id = ?", 1).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal(`SELECT * FROM users WHERE tags && ARRAY[$1] AND path <> 'C:\' AND id = $2`))
+	})
+
+	It("should keep the escape when the ?? is split between Write and WriteByte", func() {
+		ph := sqlf.DollarPlaceholder.Wrap(new(strings.Builder))
+		_, err := ph.Write([]byte("data ?"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ph.WriteByte('?')).To(Succeed())
+		_, err = ph.WriteString(" 'key' AND id = ?")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ph.String()).To(Equal("data ? 'key' AND id = $1"))
+	})
+
+	It("should flush the placeholder held at the end of the statement", func() {
+		sb := new(strings.Builder)
+		args := make([]interface{}, 0)
+		err := new(sqlf.SelectStatement).Placeholder(sqlf.DollarPlaceholder).From("users").Where("id = ?", 1).ToSQLFast(sb, &args)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sb.String()).To(Equal("SELECT * FROM users WHERE id = $1"))
+	})
+
+	It("should render concurrently with pooled writers", func() {
+		const workers = 32
+		var wg sync.WaitGroup
+		results := make([]string, workers)
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer GinkgoRecover()
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					sql, args, err := new(sqlf.SelectStatement).
+						Placeholder(sqlf.DollarPlaceholder).
+						From("users").
+						Where("account_id = ?", i).
+						WhereCriteria(sqlf.In("id", []int{i, j})).
+						ToSQL()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(args).To(Equal([]interface{}{i, i, j}))
+					results[i] = sql
+				}
+			}(i)
+		}
+		wg.Wait()
+		for _, sql := range results {
+			Expect(sql).To(Equal("SELECT * FROM users WHERE account_id = $1 AND id IN ($2, $3)"))
+		}
+	})
+
+	It("should reset the writer when it is put back", func() {
+		ph := sqlf.ColonPlaceholder.Wrap(new(strings.Builder))
+		_, err := ph.WriteString("a = ?")
+		Expect(err).ToNot(HaveOccurred())
+		sqlf.ColonPlaceholder.Put(ph)
+
+		ph = sqlf.ColonPlaceholder.Wrap(new(strings.Builder))
+		_, err = ph.WriteString("b = ?")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ph.String()).To(Equal("b = :1"))
+	})
+
+	Describe("Named", func() {
+		It("should bind the args as named args", func() {
+			sql, args, err := new(sqlf.SelectStatement).
+				Placeholder(sqlf.NamedAtPlaceholder).
+				From("users").
+				Where("account_id = ?", 7).
+				WhereCriteria(sqlf.In("id", []int{1, 2})).
+				Limit(10).
+				ToSQL()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sql).To(Equal("SELECT * FROM users WHERE account_id = @p1 AND id IN (@p2, @p3) LIMIT @p4"))
+			Expect(args).To(Equal([]interface{}{
+				dbsql.Named("p1", 7),
+				dbsql.Named("p2", 1),
+				dbsql.Named("p3", 2),
+				dbsql.Named("p4", 10),
+			}))
+		})
+
+		It("should rename named args", func() {
+			sql, args, err := new(sqlf.UpdateStatement).
+				Placeholder(sqlf.NamedColonPlaceholder).
+				Table("users").
+				Set("name", dbsql.Named("name", "Name 1")).
+				Where("id = ?", 1).
+				ToSQL()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sql).To(Equal("UPDATE users SET name = :p1 WHERE id = :p2"))
+			Expect(args).To(Equal([]interface{}{dbsql.Named("p1", "Name 1"), dbsql.Named("p2", 1)}))
+		})
+
+		It("should only format the args of the statement", func() {
+			sb, args := new(strings.Builder), []interface{}{"previous"}
+			err := new(sqlf.DeleteStatement).Placeholder(sqlf.NamedAtPlaceholder).From("users").Where("id = ?", 1).ToSQLFast(sb, &args)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(args).To(Equal([]interface{}{"previous", dbsql.Named("p1", 1)}))
+		})
+	})
 })
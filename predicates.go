@@ -0,0 +1,324 @@
+package sqlf
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"sort"
+)
+
+var (
+	sqlPredicateEq          = []byte(" = ")
+	sqlPredicateNotEq       = []byte(" <> ")
+	sqlPredicateLt          = []byte(" < ")
+	sqlPredicateLte         = []byte(" <= ")
+	sqlPredicateGt          = []byte(" > ")
+	sqlPredicateGte         = []byte(" >= ")
+	sqlPredicateIn          = []byte(" IN ")
+	sqlPredicateNotIn       = []byte(" NOT IN ")
+	sqlPredicateBetween     = []byte(" BETWEEN ")
+	sqlPredicateLike        = []byte(" LIKE ")
+	sqlPredicateILike       = []byte(" ILIKE ")
+	sqlPredicateIsNull      = []byte(" IS NULL")
+	sqlPredicateIsNotNull   = []byte(" IS NOT NULL")
+	sqlPredicatePlaceholder = []byte("?")
+	sqlPredicateAlwaysTrue  = []byte("(1=1)")
+	sqlPredicateAlwaysFalse = []byte("(1=0)")
+)
+
+// comparison renders a binary predicate in the form `<column> <operator> <value>`.
+type comparison struct {
+	column   interface{}
+	operator []byte
+	value    interface{}
+	// feature is checked against the dialect, when the operator is not supported by every database.
+	feature Feature
+}
+
+// ToSQLFast generates the SQL and returns it, alongside its params.
+func (c *comparison) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
+	if c.feature != "" {
+		err := checkFeature(sb, c.feature)
+		if err != nil {
+			return err
+		}
+	}
+	err := RenderInterfaceAsSQL(sb, args, c.column)
+	if err != nil {
+		return err
+	}
+	sb.Write(c.operator)
+	return renderPredicateValue(sb, args, c.value)
+}
+
+// nullCheck renders the `IS NULL` and `IS NOT NULL` predicates.
+type nullCheck struct {
+	column   interface{}
+	operator []byte
+}
+
+// ToSQLFast generates the SQL and returns it, alongside its params.
+func (c *nullCheck) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
+	err := RenderInterfaceAsSQL(sb, args, c.column)
+	if err != nil {
+		return err
+	}
+	sb.Write(c.operator)
+	return nil
+}
+
+// inPredicate renders the `IN` and `NOT IN` predicates.
+type inPredicate struct {
+	column   interface{}
+	operator []byte
+	values   interface{}
+	// empty is rendered, instead of the predicate, when `values` is an empty slice.
+	empty []byte
+}
+
+// ToSQLFast generates the SQL and returns it, alongside its params.
+func (in *inPredicate) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
+	if sub, ok := in.values.(FastSqlizer); ok {
+		err := RenderInterfaceAsSQL(sb, args, in.column)
+		if err != nil {
+			return err
+		}
+		sb.Write(in.operator)
+		sb.Write(sqlBracketOpen)
+		err = sub.ToSQLFast(sb, args)
+		if err != nil {
+			return err
+		}
+		sb.Write(sqlBracketClose)
+		return nil
+	}
+
+	values, isSlice := expandSlice(in.values)
+	if !isSlice {
+		values = []interface{}{in.values}
+	}
+	if len(values) == 0 {
+		sb.Write(in.empty)
+		return nil
+	}
+
+	err := RenderInterfaceAsSQL(sb, args, in.column)
+	if err != nil {
+		return err
+	}
+	sb.Write(in.operator)
+	sb.Write(sqlBracketOpen)
+	for idx, value := range values {
+		if idx > 0 {
+			sb.Write(sqlComma)
+		}
+		err := renderPredicateValue(sb, args, value)
+		if err != nil {
+			return err
+		}
+	}
+	sb.Write(sqlBracketClose)
+	return nil
+}
+
+// between renders the `BETWEEN` predicate.
+type between struct {
+	column interface{}
+	low    interface{}
+	high   interface{}
+}
+
+// ToSQLFast generates the SQL and returns it, alongside its params.
+func (b *between) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
+	err := RenderInterfaceAsSQL(sb, args, b.column)
+	if err != nil {
+		return err
+	}
+	sb.Write(sqlPredicateBetween)
+	err = renderPredicateValue(sb, args, b.low)
+	if err != nil {
+		return err
+	}
+	sb.Write(sqlConditionAnd)
+	return renderPredicateValue(sb, args, b.high)
+}
+
+// Eq renders `<column> = ?`.
+//
+// If `value` is nil, it renders `<column> IS NULL`. If `value` is a slice (except `[]byte`), it renders
+// `<column> IN (?, ?, ...)`. If `value` is a `Select`, it renders a scalar subquery `<column> = (SELECT ...)`, the same
+// applies to all the other comparisons.
+func Eq(column interface{}, value interface{}) FastSqlizer {
+	if isNil(value) {
+		return IsNull(column)
+	}
+	if isExpandableSlice(value) {
+		return In(column, value)
+	}
+	return &comparison{column: column, operator: sqlPredicateEq, value: value}
+}
+
+// NotEq renders `<column> <> ?`.
+//
+// If `value` is nil, it renders `<column> IS NOT NULL`. If `value` is a slice (except `[]byte`), it renders
+// `<column> NOT IN (?, ?, ...)`.
+func NotEq(column interface{}, value interface{}) FastSqlizer {
+	if isNil(value) {
+		return IsNotNull(column)
+	}
+	if isExpandableSlice(value) {
+		return NotIn(column, value)
+	}
+	return &comparison{column: column, operator: sqlPredicateNotEq, value: value}
+}
+
+// Lt renders `<column> < ?`.
+func Lt(column interface{}, value interface{}) FastSqlizer {
+	return &comparison{column: column, operator: sqlPredicateLt, value: value}
+}
+
+// Lte renders `<column> <= ?`.
+func Lte(column interface{}, value interface{}) FastSqlizer {
+	return &comparison{column: column, operator: sqlPredicateLte, value: value}
+}
+
+// Gt renders `<column> > ?`.
+func Gt(column interface{}, value interface{}) FastSqlizer {
+	return &comparison{column: column, operator: sqlPredicateGt, value: value}
+}
+
+// Gte renders `<column> >= ?`.
+func Gte(column interface{}, value interface{}) FastSqlizer {
+	return &comparison{column: column, operator: sqlPredicateGte, value: value}
+}
+
+// In renders `<column> IN (?, ?, ...)`, one placeholder for each element of `values`.
+//
+// `values` can be a slice, a single value or a `FastSqlizer` (like a `Select`) that is rendered as a subquery. An
+// empty slice renders an always false predicate.
+func In(column interface{}, values interface{}) FastSqlizer {
+	return &inPredicate{column: column, operator: sqlPredicateIn, values: values, empty: sqlPredicateAlwaysFalse}
+}
+
+// NotIn renders `<column> NOT IN (?, ?, ...)`, one placeholder for each element of `values`.
+//
+// `values` can be a slice, a single value or a `FastSqlizer` (like a `Select`) that is rendered as a subquery. An
+// empty slice renders an always true predicate.
+func NotIn(column interface{}, values interface{}) FastSqlizer {
+	return &inPredicate{column: column, operator: sqlPredicateNotIn, values: values, empty: sqlPredicateAlwaysTrue}
+}
+
+// Between renders `<column> BETWEEN ? AND ?`.
+func Between(column interface{}, low, high interface{}) FastSqlizer {
+	return &between{column: column, low: low, high: high}
+}
+
+// Like renders `<column> LIKE ?`.
+func Like(column interface{}, pattern interface{}) FastSqlizer {
+	return &comparison{column: column, operator: sqlPredicateLike, value: pattern}
+}
+
+// ILike renders `<column> ILIKE ?`. ILIKE is a Postgres extension.
+func ILike(column interface{}, pattern interface{}) FastSqlizer {
+	return &comparison{column: column, operator: sqlPredicateILike, value: pattern, feature: FeatureILike}
+}
+
+// IsNull renders `<column> IS NULL`.
+func IsNull(column interface{}) FastSqlizer {
+	return &nullCheck{column: column, operator: sqlPredicateIsNull}
+}
+
+// IsNotNull renders `<column> IS NOT NULL`.
+func IsNotNull(column interface{}) FastSqlizer {
+	return &nullCheck{column: column, operator: sqlPredicateIsNotNull}
+}
+
+// EqMap is a multi column equality predicate. Each key is a column and each value is rendered as in `Eq`. The
+// columns are joined with the AND operator and rendered in alphabetical order, so the output is stable.
+//
+// Example:
+//
+//	sqlf.EqMap{"name": "John", "deleted_at": nil} // (deleted_at IS NULL AND name = ?)
+type EqMap map[string]interface{}
+
+// ToSQLFast generates the SQL and returns it, alongside its params.
+func (eq EqMap) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
+	if len(eq) == 0 {
+		sb.Write(sqlPredicateAlwaysTrue)
+		return nil
+	}
+
+	keys := make([]string, 0, len(eq))
+	for key := range eq {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	sb.Write(sqlBracketOpen)
+	for idx, key := range keys {
+		if idx > 0 {
+			sb.Write(sqlConditionAnd)
+		}
+		err := Eq(key, eq[key]).ToSQLFast(sb, args)
+		if err != nil {
+			return err
+		}
+	}
+	sb.Write(sqlBracketClose)
+	return nil
+}
+
+// renderPredicateValue renders the value of a predicate. Registered types are rendered by their
+// `TypeRenderer.Arg`, `Select`s are rendered as scalar subqueries, other `FastSqlizer`s are rendered inline and
+// anything else is added as an argument.
+func renderPredicateValue(sb SQLWriter, args *[]interface{}, value interface{}) error {
+	if ok, err := renderRegisteredArg(sb, args, value); ok {
+		return err
+	}
+	switch v := value.(type) {
+	case Select:
+		return Subquery(v).ToSQLFast(sb, args)
+	case FastSqlizer:
+		return v.ToSQLFast(sb, args)
+	}
+	sb.Write(sqlPredicatePlaceholder)
+	*args = append(*args, value)
+	return nil
+}
+
+// isNil returns true when the value is nil or a nil pointer.
+func isNil(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
+
+// isExpandableSlice returns true when the value is a slice or an array that should be expanded into multiple
+// arguments. Bytes (`[]byte`, `json.RawMessage` or `[16]byte` UUIDs) and `driver.Valuer`s are not considered
+// expandable.
+func isExpandableSlice(value interface{}) bool {
+	switch value.(type) {
+	case nil, []byte, string, driver.Valuer:
+		return false
+	}
+	t := reflect.TypeOf(value)
+	kind := t.Kind()
+	return (kind == reflect.Slice || kind == reflect.Array) && t.Elem().Kind() != reflect.Uint8
+}
+
+// expandSlice returns the elements of `value` when it is an expandable slice (see `isExpandableSlice`).
+func expandSlice(value interface{}) ([]interface{}, bool) {
+	if !isExpandableSlice(value) {
+		return nil, false
+	}
+	if values, ok := value.([]interface{}); ok {
+		return values, true
+	}
+	v := reflect.ValueOf(value)
+	values := make([]interface{}, v.Len())
+	for i := range values {
+		values[i] = v.Index(i).Interface()
+	}
+	return values, true
+}
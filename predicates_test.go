@@ -0,0 +1,90 @@
+package sqlf_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/jamillosantos/sqlf"
+	"github.com/jamillosantos/sqlf/testingutils"
+)
+
+var _ = Describe("Predicates", func() {
+	render := func(sqlizer sqlf.FastSqlizer) (string, []interface{}, error) {
+		sb, args := new(strings.Builder), make([]interface{}, 0)
+		err := sqlizer.ToSQLFast(sb, &args)
+		return sb.String(), args, err
+	}
+
+	DescribeTable("comparisons",
+		func(sqlizer sqlf.FastSqlizer, expectedSQL string, expectedArgs []interface{}) {
+			sql, args, err := render(sqlizer)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sql).To(Equal(expectedSQL))
+			Expect(args).To(Equal(expectedArgs))
+		},
+		Entry("Eq", sqlf.Eq("id", 1), "id = ?", []interface{}{1}),
+		Entry("Eq with nil", sqlf.Eq("deleted_at", nil), "deleted_at IS NULL", []interface{}{}),
+		Entry("Eq with nil pointer", sqlf.Eq("deleted_at", (*int)(nil)), "deleted_at IS NULL", []interface{}{}),
+		Entry("Eq with slice", sqlf.Eq("id", []int{1, 2}), "id IN (?, ?)", []interface{}{1, 2}),
+		Entry("Eq with bytes", sqlf.Eq("hash", []byte("abc")), "hash = ?", []interface{}{[]byte("abc")}),
+		Entry("Eq with a byte array", sqlf.Eq("id", [4]byte{1, 2, 3, 4}), "id = ?", []interface{}{[4]byte{1, 2, 3, 4}}),
+		Entry("Eq with raw JSON", sqlf.Eq("data", json.RawMessage(`{}`)), "data = ?", []interface{}{json.RawMessage(`{}`)}),
+		Entry("Eq with an array", sqlf.Eq("id", [2]int{1, 2}), "id IN (?, ?)", []interface{}{1, 2}),
+		Entry("Eq with expression", sqlf.Eq("a.id", sqlf.Condition("b.a_id")), "a.id = b.a_id", []interface{}{}),
+		Entry("NotEq", sqlf.NotEq("id", 1), "id <> ?", []interface{}{1}),
+		Entry("NotEq with nil", sqlf.NotEq("deleted_at", nil), "deleted_at IS NOT NULL", []interface{}{}),
+		Entry("NotEq with slice", sqlf.NotEq("id", []int{1, 2}), "id NOT IN (?, ?)", []interface{}{1, 2}),
+		Entry("Lt", sqlf.Lt("age", 18), "age < ?", []interface{}{18}),
+		Entry("Lte", sqlf.Lte("age", 18), "age <= ?", []interface{}{18}),
+		Entry("Gt", sqlf.Gt("age", 18), "age > ?", []interface{}{18}),
+		Entry("Gte", sqlf.Gte("age", 18), "age >= ?", []interface{}{18}),
+		Entry("In", sqlf.In("id", []int{1, 2, 3}), "id IN (?, ?, ?)", []interface{}{1, 2, 3}),
+		Entry("In with single value", sqlf.In("id", 1), "id IN (?)", []interface{}{1}),
+		Entry("In with empty slice", sqlf.In("id", []int{}), "(1=0)", []interface{}{}),
+		Entry("In with expression", sqlf.In("id", sqlf.Condition("SELECT user_id FROM admins")), "id IN (SELECT user_id FROM admins)", []interface{}{}),
+		Entry("NotIn", sqlf.NotIn("id", []string{"a", "b"}), "id NOT IN (?, ?)", []interface{}{"a", "b"}),
+		Entry("NotIn with empty slice", sqlf.NotIn("id", []string{}), "(1=1)", []interface{}{}),
+		Entry("Between", sqlf.Between("age", 18, 35), "age BETWEEN ? AND ?", []interface{}{18, 35}),
+		Entry("Like", sqlf.Like("name", "J%"), "name LIKE ?", []interface{}{"J%"}),
+		Entry("ILike", sqlf.ILike("name", "j%"), "name ILIKE ?", []interface{}{"j%"}),
+		Entry("IsNull", sqlf.IsNull("deleted_at"), "deleted_at IS NULL", []interface{}{}),
+		Entry("IsNotNull", sqlf.IsNotNull("deleted_at"), "deleted_at IS NOT NULL", []interface{}{}),
+		Entry("EqMap", sqlf.EqMap{"name": "John", "deleted_at": nil, "age": []int{18, 19}}, "(age IN (?, ?) AND deleted_at IS NULL AND name = ?)", []interface{}{18, 19, "John"}),
+		Entry("EqMap empty", sqlf.EqMap{}, "(1=1)", []interface{}{}),
+	)
+
+	It("should fail rendering an errored column", func() {
+		_, _, err := render(sqlf.Eq(&testingutils.MockerSqlizer{
+			Err: errors.New("forced error"),
+		}, 1))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("forced error"))
+	})
+
+	It("should fail rendering an errored value", func() {
+		_, _, err := render(sqlf.In("id", []interface{}{1, &testingutils.MockerSqlizer{
+			Err: errors.New("forced error"),
+		}}))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("forced error"))
+	})
+
+	It("should be used as a criteria", func() {
+		sql, args, err := new(sqlf.SelectStatement).
+			Placeholder(sqlf.DollarPlaceholder).
+			From("users").
+			WhereCriteria(
+				sqlf.EqMap{"active": true, "role": []string{"admin", "owner"}},
+				sqlf.Or(sqlf.IsNull("deleted_at"), sqlf.Gt("deleted_at", "2020-01-01")),
+			).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(Equal([]interface{}{true, "admin", "owner", "2020-01-01"}))
+		Expect(sql).To(Equal("SELECT * FROM users WHERE (active = $1 AND role IN ($2, $3)) AND (deleted_at IS NULL OR deleted_at > $4)"))
+	})
+})
@@ -0,0 +1,131 @@
+package sqlf
+
+import (
+	"reflect"
+	"sync"
+)
+
+// TypeRenderFunc renders `value` into `sb`, appending its args.
+type TypeRenderFunc func(sb SQLWriter, args *[]interface{}, value interface{}) error
+
+// TypeRenderer defines how the values of a Go type are rendered.
+type TypeRenderer struct {
+	// SQL renders the value where SQL is expected: fields, columns and expressions (check `RenderInterfaceAsSQL`).
+	// When nil, the default rendering is used.
+	SQL TypeRenderFunc
+
+	// Arg renders the value where an argument is expected: predicate values, `Arg`, the values of inserts and
+	// updates, LIMIT and OFFSET (check `RenderInterfaceAsArg`). Ex: `?::numeric` binding a converted value. When
+	// nil, the value is bound unchanged.
+	//
+	// The args of a `Condition` are bound as they are, as their placeholders are part of its SQL.
+	Arg TypeRenderFunc
+}
+
+// Registry maps Go types to the way their values are rendered, so domain types work everywhere without wrappers.
+//
+// A registry is attached to a `Builder` (check `Builder.Registry`) or to a dialect (check `WithRegistry`). It is
+// safe for concurrent use, but the types should be registered before rendering any statement.
+type Registry struct {
+	mu        sync.RWMutex
+	renderers map[reflect.Type]TypeRenderer
+}
+
+// NewRegistry creates an empty `Registry`.
+func NewRegistry() *Registry {
+	return &Registry{
+		renderers: make(map[reflect.Type]TypeRenderer),
+	}
+}
+
+// Register defines how the values of the type of `sample` are rendered. Ex:
+//
+//	registry.Register(Money{}, sqlf.TypeRenderer{
+//		Arg: func(sb sqlf.SQLWriter, args *[]interface{}, value interface{}) error {
+//			sb.WriteString("?::numeric")
+//			*args = append(*args, value.(Money).Decimal())
+//			return nil
+//		},
+//	})
+//
+// Only values of that exact type are matched (a pointer to it is a different type).
+func (r *Registry) Register(sample interface{}, renderer TypeRenderer) *Registry {
+	r.mu.Lock()
+	r.renderers[reflect.TypeOf(sample)] = renderer
+	r.mu.Unlock()
+	return r
+}
+
+// lookup returns the `TypeRenderer` registered for the type of `value`.
+func (r *Registry) lookup(value interface{}) (TypeRenderer, bool) {
+	if value == nil {
+		return TypeRenderer{}, false
+	}
+	r.mu.RLock()
+	renderer, ok := r.renderers[reflect.TypeOf(value)]
+	r.mu.RUnlock()
+	return renderer, ok
+}
+
+// registryDialect is a dialect that owns a `Registry`.
+type registryDialect struct {
+	Dialect
+	registry *Registry
+}
+
+// WithRegistry returns a copy of the `dialect` that owns the `registry`. Statements rendered for the returned
+// dialect use the `registry`, unless their `Builder` defines another one. Ex:
+//
+//	var Postgres = sqlf.WithRegistry(sqlf.Postgres, registry)
+func WithRegistry(dialect Dialect, registry *Registry) Dialect {
+	if d, ok := dialect.(*registryDialect); ok {
+		dialect = d.Dialect
+	}
+	return &registryDialect{
+		Dialect:  dialect,
+		registry: registry,
+	}
+}
+
+// registryOf returns the `Registry` of the statement being rendered, falling back to the one of its dialect.
+func registryOf(sb SQLWriter) *Registry {
+	ctx, ok := contextOf(sb)
+	if !ok {
+		return nil
+	}
+	if ctx.registry != nil {
+		return ctx.registry
+	}
+	if d, ok := ctx.dialect.(*registryDialect); ok {
+		return d.registry
+	}
+	return nil
+}
+
+// renderRegisteredSQL renders `element` with the `TypeRenderer.SQL` registered for its type. It returns false
+// when there is none.
+func renderRegisteredSQL(sb SQLWriter, args *[]interface{}, element interface{}) (bool, error) {
+	registry := registryOf(sb)
+	if registry == nil {
+		return false, nil
+	}
+	renderer, ok := registry.lookup(element)
+	if !ok || renderer.SQL == nil {
+		return false, nil
+	}
+	return true, renderer.SQL(sb, args, element)
+}
+
+// renderRegisteredArg renders `element` with the `TypeRenderer.Arg` registered for its type. It returns false
+// when there is none.
+func renderRegisteredArg(sb SQLWriter, args *[]interface{}, element interface{}) (bool, error) {
+	registry := registryOf(sb)
+	if registry == nil {
+		return false, nil
+	}
+	renderer, ok := registry.lookup(element)
+	if !ok || renderer.Arg == nil {
+		return false, nil
+	}
+	return true, renderer.Arg(sb, args, element)
+}
@@ -0,0 +1,136 @@
+package sqlf_test
+
+import (
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/jamillosantos/sqlf"
+)
+
+// cents is a money amount, stored as numeric.
+type cents int64
+
+// point is a geometry, stored as PostGIS.
+type point struct {
+	X, Y float64
+}
+
+func newTestRegistry() *sqlf.Registry {
+	return sqlf.NewRegistry().
+		Register(cents(0), sqlf.TypeRenderer{
+			Arg: func(sb sqlf.SQLWriter, args *[]interface{}, value interface{}) error {
+				sb.WriteString("?::numeric")
+				*args = append(*args, fmt.Sprintf("%.2f", float64(value.(cents))/100))
+				return nil
+			},
+		}).
+		Register(point{}, sqlf.TypeRenderer{
+			SQL: func(sb sqlf.SQLWriter, args *[]interface{}, value interface{}) error {
+				p := value.(point)
+				sb.WriteString(fmt.Sprintf("ST_MakePoint(%g, %g)", p.X, p.Y))
+				return nil
+			},
+			Arg: func(sb sqlf.SQLWriter, args *[]interface{}, value interface{}) error {
+				p := value.(point)
+				sb.WriteString("ST_GeomFromText(?)")
+				*args = append(*args, fmt.Sprintf("POINT(%g %g)", p.X, p.Y))
+				return nil
+			},
+		})
+}
+
+var _ = Describe("Registry", func() {
+	It("should render registered types as args of predicates and functions", func() {
+		sql, args, err := sqlf.NewBuilder().
+			Placeholder(sqlf.DollarPlaceholder).
+			Registry(newTestRegistry()).
+			Select().
+			Select("id", sqlf.Func("ST_Distance", "location", sqlf.Arg(point{1, 2}))).
+			From("stores").
+			WhereCriteria(sqlf.Gt("balance", cents(1050)), sqlf.In("fee", []cents{100, 250})).
+			Where("active = ?", true).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("SELECT id, ST_Distance(location, ST_GeomFromText($1)) FROM stores WHERE balance > $2::numeric AND fee IN ($3::numeric, $4::numeric) AND active = $5"))
+		Expect(args).To(Equal([]interface{}{"POINT(1 2)", "10.50", "1.00", "2.50", true}))
+	})
+
+	It("should render registered types as SQL", func() {
+		sql, _, err := sqlf.NewBuilder().
+			Registry(newTestRegistry()).
+			Select().
+			Select(point{3, 4}).
+			From("dual").
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("SELECT ST_MakePoint(3, 4) FROM dual"))
+	})
+
+	It("should render registered types of inserts and updates", func() {
+		b := sqlf.NewBuilder().Registry(newTestRegistry())
+		sql, args, err := b.Insert("accounts", "name", "balance").
+			Values("a", cents(100)).
+			Values("b", cents(5)).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("INSERT INTO accounts (name, balance) VALUES (?,?::numeric), (?,?::numeric)"))
+		Expect(args).To(Equal([]interface{}{"a", "1.00", "b", "0.05"}))
+
+		sql, args, err = b.Update("accounts").Set("balance", cents(1)).Where("id = ?", 1).ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("UPDATE accounts SET balance = ?::numeric WHERE id = ?"))
+		Expect(args).To(Equal([]interface{}{"0.01", 1}))
+	})
+
+	It("should use the registry of the dialect", func() {
+		dialect := sqlf.WithRegistry(sqlf.Postgres, newTestRegistry())
+		sql, args, err := new(sqlf.SelectStatement).
+			Dialect(dialect).
+			From("accounts").
+			WhereCriteria(sqlf.Eq("balance", cents(1))).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal(`SELECT * FROM accounts WHERE balance = $1::numeric`))
+		Expect(args).To(Equal([]interface{}{"0.01"}))
+		Expect(dialect.Name()).To(Equal("postgres"))
+	})
+
+	It("should prefer the registry of the builder to the one of the dialect", func() {
+		dialectRegistry := sqlf.NewRegistry().Register(cents(0), sqlf.TypeRenderer{
+			Arg: func(sb sqlf.SQLWriter, args *[]interface{}, value interface{}) error {
+				sb.WriteString("?::money")
+				*args = append(*args, int64(value.(cents)))
+				return nil
+			},
+		})
+		sql, _, err := sqlf.NewBuilder().
+			Dialect(sqlf.WithRegistry(sqlf.Postgres, dialectRegistry)).
+			Registry(newTestRegistry()).
+			Select().
+			From("accounts").
+			WhereCriteria(sqlf.Eq("balance", cents(1))).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal(`SELECT * FROM accounts WHERE balance = $1::numeric`))
+	})
+
+	It("should not affect statements without a registry", func() {
+		_, args, err := new(sqlf.SelectStatement).From("accounts").WhereCriteria(sqlf.Eq("balance", cents(1))).ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(Equal([]interface{}{cents(1)}))
+	})
+
+	It("should return the error of the renderer", func() {
+		forcedErr := errors.New("forced error")
+		registry := sqlf.NewRegistry().Register(cents(0), sqlf.TypeRenderer{
+			Arg: func(sqlf.SQLWriter, *[]interface{}, interface{}) error {
+				return forcedErr
+			},
+		})
+		_, _, err := sqlf.NewBuilder().Registry(registry).Insert("accounts", "balance").Values(cents(1)).ToSQL()
+		Expect(err).To(MatchError("sqlf: INSERT VALUES row 1: forced error"))
+	})
+})
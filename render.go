@@ -0,0 +1,355 @@
+package sqlf
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// renderContext holds the options of the statement being rendered that nested `FastSqlizer`s should honor.
+type renderContext struct {
+	// dialect is the database technology the statement is rendered for. When nil, no dialect specific behavior is
+	// applied.
+	dialect Dialect
+	// quoter is used to quote identifiers. When nil, the quoter of the `dialect` is used, falling back to
+	// `DoubleQuoteIdentifier`.
+	quoter IdentifierQuoter
+	// quoteAll enables quoting every table and alias automatically.
+	quoteAll bool
+	// strict refuses values that cannot be safely rendered as SQL. Check `Select.Strict`.
+	strict bool
+	// emptySlices defines how the empty slice arguments of the conditions are rendered. Check `Select.EmptySlices`.
+	emptySlices EmptySliceStyle
+	// bindings are the values of the named parameters of the conditions. Check `Select.Bind`.
+	bindings *bindings
+	// registry defines how the registered Go types are rendered. When nil, the registry of the `dialect` is used.
+	// Check `Registry`.
+	registry *Registry
+}
+
+// lexerSyntax returns the rules used to tell the SQL code apart from literals, quoted identifiers and comments: the
+// ones of the dialect, plus the `[name]` identifiers when they are quoted by `BracketIdentifier`.
+func (ctx renderContext) lexerSyntax() lexerSyntax {
+	syntax := lexerSyntaxOf(ctx.dialect)
+	if ctx.quoter == BracketIdentifier {
+		syntax |= syntaxBrackets
+	}
+	return syntax
+}
+
+// lexerSyntaxOfWriter returns the lexer rules of the statement being rendered (check `renderContext.lexerSyntax`).
+func lexerSyntaxOfWriter(sb SQLWriter) lexerSyntax {
+	if ctx, ok := contextOf(sb); ok {
+		return ctx.lexerSyntax()
+	}
+	return 0
+}
+
+// renderWriter is a `SQLWriter` that carries the `renderContext` down to the nested `FastSqlizer`s. It is the
+// only way to reach them, as `ToSQLFast` only receives the writer and the args.
+type renderWriter struct {
+	SQLWriter
+	ctx renderContext
+}
+
+// empty reports whether the context has no options, so the statement can be rendered without a `renderWriter`.
+func (ctx *renderContext) empty() bool {
+	return ctx.dialect == nil && ctx.quoter == nil && !ctx.quoteAll && !ctx.strict &&
+		ctx.emptySlices == emptySliceDefault && ctx.bindings == nil && ctx.registry == nil
+}
+
+// renderMark records that a statement is being rendered into the writer that embeds it. It is embedded by the
+// writers created by the statements themselves (the builder of `ToSQL` and the writers of the numbered placeholder
+// formats), so they carry the empty `renderContext` and the statements without options skip the `renderWriter`.
+type renderMark struct {
+	rendering bool
+}
+
+// renderBuilder is the writer the statements render into on `ToSQL`.
+type renderBuilder struct {
+	strings.Builder
+	renderMark
+}
+
+// markOf returns the `renderMark` of the writer, if it has one.
+func markOf(sb SQLWriter) *renderMark {
+	switch w := sb.(type) {
+	case *renderBuilder:
+		return &w.renderMark
+	case *numberedPlaceholder:
+		return &w.renderMark
+	}
+	return nil
+}
+
+// contextOf returns the `renderContext` carried by the writer, if any. A marked writer being rendered into
+// carries the empty context (check `renderMark`).
+func contextOf(sb SQLWriter) (renderContext, bool) {
+	if w, ok := sb.(*renderWriter); ok {
+		return w.ctx, true
+	}
+	if mark := markOf(sb); mark != nil {
+		return renderContext{}, mark.rendering
+	}
+	return renderContext{}, false
+}
+
+// unwrapRenderWriter returns the writer wrapped by the `renderWriter`s, usually the writer of the placeholder
+// format.
+func unwrapRenderWriter(sb SQLWriter) SQLWriter {
+	for {
+		w, ok := sb.(*renderWriter)
+		if !ok {
+			return sb
+		}
+		sb = w.SQLWriter
+	}
+}
+
+// renderWriterPool recycles the `renderWriter`s created by the outermost statements.
+var renderWriterPool = sync.Pool{
+	New: func() interface{} {
+		return new(renderWriter)
+	},
+}
+
+// renderSession holds the state of a statement being rendered. It owns the writers it created, which are released
+// by `end`.
+type renderSession struct {
+	// sb is the writer the statement should be rendered into.
+	sb          SQLWriter
+	placeholder PlaceholderFormatFactory
+	// wrapped is the writer created by the `placeholder` format. It is given back to the format by `end`.
+	wrapped SQLWriter
+	// owned is the `renderWriter` taken from the `renderWriterPool`. It is given back to the pool by `end`.
+	owned *renderWriter
+	// marked is the mark of the writer that carries the empty context instead of the `owned` one. It is cleared by
+	// `end`.
+	marked *renderMark
+	args   *[]interface{}
+	// start is the length of the args when the rendering started.
+	start int
+}
+
+// beginRender prepares the rendering of a statement: wraps `sb` into the `placeholder` format (falling back to
+// the one of the dialect) and makes it carry the context of the statement.
+//
+// A statement nested into another one (Ex: the select of an INSERT ... SELECT, or a subquery) receives the writer
+// already wrapped by the outermost statement. So, it is not wrapped again: the outermost format wins and all
+// placeholders of the render pass share a single counter.
+//
+// A statement without options rendered into a writer it created (check `renderMark`) skips the `renderWriter`, as
+// the writer itself carries the empty context.
+//
+// The session must be finished by `end`, even if the rendering fails, so its writers are released.
+func beginRender(sb SQLWriter, args *[]interface{}, placeholder PlaceholderFormatFactory, ctx renderContext) renderSession {
+	if parentCtx, nested := contextOf(sb); nested {
+		return renderSession{
+			sb:    withRenderContext(sb, parentCtx, ctx),
+			args:  args,
+			start: len(*args),
+		}
+	}
+	if placeholder == nil && ctx.dialect != nil {
+		placeholder = ctx.dialect.Placeholder()
+	}
+	session := renderSession{
+		placeholder: placeholder,
+		args:        args,
+		start:       len(*args),
+	}
+	if placeholder != nil {
+		session.wrapped = placeholder.Wrap(sb)
+		if numbered, ok := session.wrapped.(*numberedPlaceholder); ok {
+			numbered.lexer.syntax = ctx.lexerSyntax()
+		}
+		sb = session.wrapped
+	}
+	if mark := markOf(sb); mark != nil && ctx.empty() {
+		mark.rendering = true
+		session.marked = mark
+		session.sb = sb
+		return session
+	}
+	session.owned = renderWriterPool.Get().(*renderWriter)
+	session.owned.SQLWriter = sb
+	session.owned.ctx = ctx
+	session.sb = session.owned
+	return session
+}
+
+// end finishes the rendering of the statement, flushing the writer of the placeholder format (check
+// `PlaceholderFlusher`) and formatting the args it added when the placeholder format is a
+// `PlaceholderArgsFormatter`. `err` is the result of the rendering, which is returned as it is.
+//
+// Nested statements have no placeholder format of their own, their args are formatted by the outermost statement.
+//
+// The writers of the session are released, so `sb` must not be used after it.
+func (session renderSession) end(err error) error {
+	defer session.release()
+	if err != nil {
+		return err
+	}
+	if flusher, ok := session.wrapped.(PlaceholderFlusher); ok {
+		err = flusher.Flush()
+		if err != nil {
+			return err
+		}
+	}
+	if formatter, ok := session.wrapped.(PlaceholderArgsFormatter); ok {
+		formatter.FormatArgs((*session.args)[session.start:])
+	} else if formatter, ok := session.placeholder.(PlaceholderArgsFormatter); ok {
+		formatter.FormatArgs((*session.args)[session.start:])
+	}
+	return nil
+}
+
+// release gives the writers created by the session back to their pools.
+func (session renderSession) release() {
+	if session.marked != nil {
+		session.marked.rendering = false
+	}
+	if session.wrapped != nil {
+		session.placeholder.Put(session.wrapped)
+	}
+	if session.owned != nil {
+		*session.owned = renderWriter{}
+		renderWriterPool.Put(session.owned)
+	}
+}
+
+// withRenderContext returns a writer carrying the context of a nested statement. The options not defined by the
+// statement are inherited from the `parentCtx` (the context of the statement it is nested into).
+//
+// If no option changes, `sb` is returned as it is.
+func withRenderContext(sb SQLWriter, parentCtx renderContext, ctx renderContext) SQLWriter {
+	changed := false
+	if ctx.quoter == nil {
+		ctx.quoter = parentCtx.quoter
+		ctx.quoteAll = parentCtx.quoteAll
+	} else if ctx.quoteAll != parentCtx.quoteAll || !sameOption(ctx.quoter, parentCtx.quoter) {
+		changed = true
+	}
+	if ctx.dialect == nil {
+		ctx.dialect = parentCtx.dialect
+	} else if !sameOption(ctx.dialect, parentCtx.dialect) {
+		changed = true
+	}
+	if ctx.strict && !parentCtx.strict {
+		changed = true
+	}
+	ctx.strict = ctx.strict || parentCtx.strict
+	if ctx.emptySlices == emptySliceDefault {
+		ctx.emptySlices = parentCtx.emptySlices
+	} else if ctx.emptySlices != parentCtx.emptySlices {
+		changed = true
+	}
+	if ctx.bindings == nil {
+		ctx.bindings = parentCtx.bindings
+	} else if ctx.bindings != parentCtx.bindings {
+		changed = true
+	}
+	if ctx.registry == nil {
+		ctx.registry = parentCtx.registry
+	} else if ctx.registry != parentCtx.registry {
+		changed = true
+	}
+	if !changed {
+		return sb
+	}
+	return &renderWriter{
+		SQLWriter: unwrapRenderWriter(sb),
+		ctx:       ctx,
+	}
+}
+
+// sameOption reports whether the options `a` and `b` (a `Dialect` or an `IdentifierQuoter`) are the same pointer.
+// Other values are never the same, as comparing them would panic when their type is not comparable.
+func sameOption(a, b interface{}) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Kind() != reflect.Ptr || vb.Kind() != reflect.Ptr {
+		return false
+	}
+	return va.Type() == vb.Type() && va.Pointer() == vb.Pointer()
+}
+
+// RenderError is returned when a statement fails to render. It records where the failure happened, so the broken
+// piece of a large dynamic query can be found, and wraps its cause (so `errors.Is` and `errors.As` work).
+//
+// Errors from nested statements are wrapped by the `RenderError` of the outer statement. Ex:
+//
+//	sqlf: SELECT WHERE #2 > SELECT JOIN #1 > ON #1 "r.id = ?": condition "r.id = ?" has 1 placeholders but 0 args
+type RenderError struct {
+	// Statement is the kind of the statement being rendered: SELECT, INSERT, UPDATE or DELETE. It is empty when
+	// the error comes from a clause rendered outside of a statement (Ex: the ON of a `Join`).
+	Statement string
+	// Clause is where the error happened, including the position (starting at 1) when the clause has many
+	// elements. Ex: "WHERE #2", "JOIN #1", "VALUES row 5", "LIMIT".
+	Clause string
+	// Element is the element that failed to render, if any.
+	Element interface{}
+	// Err is the cause.
+	Err error
+}
+
+// Error implements the `error` interface.
+func (err *RenderError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("sqlf: ")
+	var cause error = err
+	for {
+		renderErr, ok := cause.(*RenderError)
+		if !ok {
+			break
+		}
+		if renderErr != err {
+			sb.WriteString(" > ")
+		}
+		renderErr.writeLocation(&sb)
+		cause = renderErr.Err
+	}
+	if cause != nil {
+		sb.WriteString(": ")
+		sb.WriteString(strings.TrimPrefix(cause.Error(), "sqlf: "))
+	}
+	return sb.String()
+}
+
+// writeLocation writes the statement, clause and element of the error.
+func (err *RenderError) writeLocation(sb *strings.Builder) {
+	sb.WriteString(err.Statement)
+	if err.Statement != "" && err.Clause != "" {
+		sb.WriteByte(' ')
+	}
+	sb.WriteString(err.Clause)
+	switch element := err.Element.(type) {
+	case string:
+		sb.WriteByte(' ')
+		sb.WriteString(strconv.Quote(element))
+	case *condition:
+		sb.WriteByte(' ')
+		sb.WriteString(strconv.Quote(element.sql))
+	}
+}
+
+// Unwrap returns the cause of the error.
+func (err *RenderError) Unwrap() error {
+	return err.Err
+}
+
+// renderError wraps `err` into a `RenderError`.
+func renderError(statement, clause string, element interface{}, err error) error {
+	return &RenderError{
+		Statement: statement,
+		Clause:    clause,
+		Element:   element,
+		Err:       err,
+	}
+}
+
+// clauseAt returns the name of the element, at the index `idx`, of the `clause`. Ex: `clauseAt("WHERE", 1)`
+// returns "WHERE #2".
+func clauseAt(clause string, idx int) string {
+	return clause + " #" + strconv.Itoa(idx+1)
+}
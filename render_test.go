@@ -0,0 +1,154 @@
+package sqlf_test
+
+import (
+	dbsql "database/sql"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/jamillosantos/sqlf"
+	"github.com/jamillosantos/sqlf/testingutils"
+)
+
+// sliceQuoter is an `IdentifierQuoter` that is not comparable.
+type sliceQuoter []string
+
+func (q sliceQuoter) QuoteIdentifier(sb sqlf.SQLWriter, name string) {
+	sb.WriteString(q[0] + name + q[1])
+}
+
+// funcDialect is a `sqlf.Dialect` that is not comparable.
+type funcDialect struct {
+	sqlf.Dialect
+	hook func()
+}
+
+var _ = Describe("RenderError", func() {
+	forcedErr := errors.New("forced error")
+
+	It("should record the statement, clause and element", func() {
+		mock := &testingutils.MockerSqlizer{Err: forcedErr}
+		_, _, err := new(sqlf.SelectStatement).
+			From("users", "u").
+			InnerJoin("roles", "r").On("r.id = u.role_id").
+			LeftJoin("permissions", "p").OnClause(mock).
+			ToSQL()
+		Expect(errors.Is(err, forcedErr)).To(BeTrue())
+
+		var renderErr *sqlf.RenderError
+		Expect(errors.As(err, &renderErr)).To(BeTrue())
+		Expect(renderErr.Statement).To(Equal("SELECT"))
+		Expect(renderErr.Clause).To(Equal("JOIN #2"))
+
+		var joinErr *sqlf.RenderError
+		Expect(errors.As(renderErr.Err, &joinErr)).To(BeTrue())
+		Expect(joinErr.Clause).To(Equal("ON #1"))
+		Expect(joinErr.Element).To(BeIdenticalTo(mock))
+		Expect(joinErr.Err).To(BeIdenticalTo(forcedErr))
+	})
+
+	It("should point to the incomplete row of the VALUES", func() {
+		_, _, err := new(sqlf.InsertStatement).
+			Into("users", "name", "email").
+			Values("Name 1", "email1@email.com").
+			Values("Name 2", "email2@email.com").
+			Values("Name 3").
+			ToSQL()
+		var renderErr *sqlf.RenderError
+		Expect(errors.As(err, &renderErr)).To(BeTrue())
+		Expect(renderErr.Clause).To(Equal("VALUES row 3"))
+		Expect(renderErr.Element).To(Equal([]interface{}{"Name 3"}))
+		Expect(errors.Is(err, sqlf.ErrMismatchFieldsAndValuesCount)).To(BeTrue())
+	})
+
+	It("should describe the path of nested statements", func() {
+		_, _, err := new(sqlf.DeleteStatement).
+			From("users").
+			Where("active = ?", false).
+			WhereClause(sqlf.In("id", new(sqlf.SelectStatement).Select("user_id").From("bans").Where("until > ?"))).
+			ToSQL()
+		Expect(err).To(MatchError(`sqlf: DELETE WHERE #2 > SELECT WHERE #1 "until > ?": condition "until > ?" has 1 placeholders but 0 args`))
+	})
+})
+
+var _ = Describe("Placeholder numbering", func() {
+	It("should share the counter with the select of an INSERT ... SELECT", func() {
+		b := sqlf.NewBuilder().Placeholder(sqlf.DollarPlaceholder)
+		sql, args, err := b.Insert("archived_users", "id", "name").
+			Select(func(s sqlf.Select) {
+				s.Select("id", "name").From("users").Where("active = ?", false).Where("last_login < ?", 2020)
+			}).
+			OnConflict(func(c sqlf.InsertConflict) {
+				c.Target("id").Update(func(u sqlf.InsertUpdate) {
+					u.Set("name", sqlf.Excluded("name")).Where("archived_users.version < ?", 3)
+				})
+			}).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("INSERT INTO archived_users (id, name) SELECT id, name FROM users WHERE active = $1 AND last_login < $2 ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name WHERE archived_users.version < $3"))
+		Expect(args).To(Equal([]interface{}{false, 2020, 3}))
+	})
+
+	It("should share the counter with subqueries created by the same builder", func() {
+		b := sqlf.NewBuilder().Placeholder(sqlf.DollarPlaceholder)
+		sql, args, err := b.Select().Select("id").
+			From("users").
+			Where("account_id = ?", 7).
+			WhereCriteria(sqlf.In("id", b.Select().Select("user_id").From("orders").Where("total > ?", 100))).
+			Where("active = ?", true).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("SELECT id FROM users WHERE account_id = $1 AND id IN (SELECT user_id FROM orders WHERE total > $2) AND active = $3"))
+		Expect(args).To(Equal([]interface{}{7, 100, true}))
+	})
+
+	It("should use the format of the outermost statement", func() {
+		sql, args, err := new(sqlf.SelectStatement).
+			From("users").
+			Where("account_id = ?", 7).
+			WhereCriteria(sqlf.In("id", new(sqlf.SelectStatement).Placeholder(sqlf.DollarPlaceholder).Select("user_id").From("orders").Where("total > ?", 100))).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("SELECT * FROM users WHERE account_id = ? AND id IN (SELECT user_id FROM orders WHERE total > ?)"))
+		Expect(args).To(Equal([]interface{}{7, 100}))
+	})
+
+	It("should format the args of nested statements once", func() {
+		sql, args, err := new(sqlf.DeleteStatement).
+			Placeholder(sqlf.NamedAtPlaceholder).
+			From("users").
+			WhereClause(sqlf.In("id", new(sqlf.SelectStatement).Placeholder(sqlf.NamedAtPlaceholder).Select("user_id").From("bans").Where("until > ?", 10))).
+			Where("active = ?", false).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("DELETE FROM users WHERE id IN (SELECT user_id FROM bans WHERE until > @p1) AND active = @p2"))
+		Expect(args).To(Equal([]interface{}{dbsql.Named("p1", 10), dbsql.Named("p2", false)}))
+	})
+
+	It("should apply the options of a statement nested into one without options", func() {
+		outer := new(sqlf.SelectStatement).
+			From("users").
+			WhereCriteria(sqlf.In("id", new(sqlf.SelectStatement).Placeholder(sqlf.DollarPlaceholder).QuoteIdentifiers(sqlf.DoubleQuoteIdentifier).Select("user_id").From("orders").Where("total > ?", 100))).
+			Where("active = ?", true)
+		for i := 0; i < 2; i++ {
+			sql, args, err := outer.ToSQL()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sql).To(Equal(`SELECT * FROM users WHERE id IN (SELECT user_id FROM "orders" WHERE total > ?) AND active = ?`))
+			Expect(args).To(Equal([]interface{}{100, true}))
+		}
+	})
+
+	It("should nest statements with options that are not comparable", func() {
+		dialect := funcDialect{Dialect: sqlf.Postgres, hook: func() {}}
+		quoter := sliceQuoter{"[", "]"}
+		sql, _, err := new(sqlf.SelectStatement).
+			Dialect(dialect).
+			QuoteIdentifiers(quoter).
+			From("users").
+			WhereCriteria(sqlf.In("id", new(sqlf.SelectStatement).Dialect(dialect).QuoteIdentifiers(quoter).Select("user_id").From("orders").Where("total > ?", 100))).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("SELECT * FROM [users] WHERE id IN (SELECT user_id FROM [orders] WHERE total > $1)"))
+	})
+})
@@ -0,0 +1,213 @@
+package sqlf
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// ErrInvalidScanDestination is returned when the destination of `ScanOne` is not a pointer to a struct, or the
+	// destination of `ScanAll` is not a pointer to a slice of structs (or of pointers to structs).
+	ErrInvalidScanDestination = errors.New("invalid scan destination")
+
+	// ErrUnmappedColumn is the error matched, through `errors.Is`, by any `UnmappedColumnError`.
+	ErrUnmappedColumn = errors.New("unmapped column")
+)
+
+// UnmappedColumnError is returned when a column of the scanned rows has no field in the destination struct.
+type UnmappedColumnError struct {
+	Column string
+	Type   reflect.Type
+}
+
+// Error implements the `error` interface.
+func (err *UnmappedColumnError) Error() string {
+	return fmt.Sprintf("sqlf: the column %q has no field in %s", err.Column, err.Type)
+}
+
+// Is makes `errors.Is(err, ErrUnmappedColumn)` match.
+func (err *UnmappedColumnError) Is(target error) bool {
+	return target == ErrUnmappedColumn
+}
+
+// ScanOne scans the first row of `rows` into the struct pointed by `dest`, closing the `rows`. It returns
+// `sql.ErrNoRows` when there is no row. Ex:
+//
+//	var user User
+//	err := sqlf.ScanOne(rows, &user)
+//
+// Columns are mapped to the fields by their `db` tag, falling back to the field name. Fields tagged with
+// `db:"-"` are ignored, and embedded structs (or pointers to them) are flattened. Fields are scanned as
+// `sql.Rows.Scan` does: pointers are set to nil for NULL values and `sql.Scanner` fields scan themselves. A column
+// without a field returns an `UnmappedColumnError`.
+func ScanOne(rows *sql.Rows, dest interface{}) error {
+	defer rows.Close()
+
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("sqlf: %w: expected a pointer to a struct, got %T", ErrInvalidScanDestination, dest)
+	}
+	v = v.Elem()
+
+	indexes, err := columnIndexes(rows, v.Type())
+	if err != nil {
+		return err
+	}
+	if !rows.Next() {
+		err = rows.Err()
+		if err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+	err = scanStruct(rows, v, indexes, make([]interface{}, len(indexes)))
+	if err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+// ScanAll scans all the `rows` into the slice pointed by `dest`, closing the `rows`. The slice elements are structs
+// or pointers to structs, and the existing elements are replaced. Ex:
+//
+//	var users []User
+//	err := sqlf.ScanAll(rows, &users)
+//
+// Check `ScanOne` for how columns are mapped to the fields.
+func ScanAll(rows *sql.Rows, dest interface{}) error {
+	defer rows.Close()
+
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("sqlf: %w: expected a pointer to a slice of structs, got %T", ErrInvalidScanDestination, dest)
+	}
+	slice := v.Elem()
+	elemType := slice.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+	structType := elemType
+	if isPtr {
+		structType = elemType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("sqlf: %w: expected a pointer to a slice of structs, got %T", ErrInvalidScanDestination, dest)
+	}
+
+	indexes, err := columnIndexes(rows, structType)
+	if err != nil {
+		return err
+	}
+	slice.SetLen(0)
+	targets := make([]interface{}, len(indexes))
+	for rows.Next() {
+		elem := reflect.New(structType)
+		err = scanStruct(rows, elem.Elem(), indexes, targets)
+		if err != nil {
+			return err
+		}
+		if !isPtr {
+			elem = elem.Elem()
+		}
+		slice.Set(reflect.Append(slice, elem))
+	}
+	err = rows.Err()
+	if err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+// ScanOneMap scans the first row of `rows` into a map of the columns to their values, closing the `rows`. It returns
+// `sql.ErrNoRows` when there is no row.
+func ScanOneMap(rows *sql.Rows) (map[string]interface{}, error) {
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	if !rows.Next() {
+		err = rows.Err()
+		if err != nil {
+			return nil, err
+		}
+		return nil, sql.ErrNoRows
+	}
+	row, err := scanMap(rows, columns, make([]interface{}, len(columns)))
+	if err != nil {
+		return nil, err
+	}
+	return row, rows.Close()
+}
+
+// ScanAllMaps scans all the `rows` into maps of the columns to their values, closing the `rows`.
+func ScanAllMaps(rows *sql.Rows) ([]map[string]interface{}, error) {
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]map[string]interface{}, 0)
+	targets := make([]interface{}, len(columns))
+	for rows.Next() {
+		row, err := scanMap(rows, columns, targets)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+	return result, rows.Close()
+}
+
+// columnIndexes returns the path of the field, of the struct type `t`, of each column of `rows`.
+func columnIndexes(rows *sql.Rows, t reflect.Type) ([][]int, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	metadata := structMetadataOf(t)
+	indexes := make([][]int, len(columns))
+	for i, column := range columns {
+		field, ok := metadata.field(column)
+		if !ok {
+			return nil, &UnmappedColumnError{
+				Column: column,
+				Type:   t,
+			}
+		}
+		indexes[i] = field.index
+	}
+	return indexes, nil
+}
+
+// scanStruct scans the current row of `rows` into the struct `v`. `targets` is a buffer for the field pointers.
+func scanStruct(rows *sql.Rows, v reflect.Value, indexes [][]int, targets []interface{}) error {
+	for i, index := range indexes {
+		field, _ := fieldByIndex(v, index, true)
+		targets[i] = field.Addr().Interface()
+	}
+	return rows.Scan(targets...)
+}
+
+// scanMap scans the current row of `rows` into a new map. `targets` is a buffer for the value pointers.
+func scanMap(rows *sql.Rows, columns []string, targets []interface{}) (map[string]interface{}, error) {
+	values := make([]interface{}, len(columns))
+	for i := range values {
+		targets[i] = &values[i]
+	}
+	err := rows.Scan(targets...)
+	if err != nil {
+		return nil, err
+	}
+	row := make(map[string]interface{}, len(columns))
+	for i, column := range columns {
+		row[column] = values[i]
+	}
+	return row, nil
+}
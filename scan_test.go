@@ -0,0 +1,190 @@
+package sqlf_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/jamillosantos/sqlf"
+	"github.com/jamillosantos/sqlf/testingutils"
+)
+
+type scanAudit struct {
+	CreatedAt time.Time `db:"created_at"`
+	Name      string    `db:"name"`
+}
+
+// Profile is embedded by pointer into `scanUser`.
+type Profile struct {
+	Bio *string `db:"bio"`
+}
+
+type scanUser struct {
+	ID       int64          `db:"id"`
+	Name     string         `db:"name"`
+	Email    sql.NullString `db:"email"`
+	Nickname *string        `db:"nickname"`
+	Ignored  string         `db:"-"`
+	Age      int
+	scanAudit
+	*Profile
+}
+
+var _ = Describe("Scan", func() {
+	var (
+		ctx       context.Context
+		fd        *testingutils.FakeDriver
+		db        *sql.DB
+		createdAt = time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC)
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fd = &testingutils.FakeDriver{
+			Columns: []string{"id", "name", "email", "nickname", "Age", "created_at", "bio"},
+			Rows: [][]driver.Value{
+				{int64(1), "john", "john@example.com", "johnny", int64(30), createdAt, "hi"},
+				{int64(2), "jane", nil, nil, int64(25), createdAt, nil},
+			},
+		}
+		db = fd.DB()
+	})
+
+	AfterEach(func() {
+		Expect(db.Close()).To(Succeed())
+	})
+
+	query := func() *sql.Rows {
+		rows, err := db.QueryContext(ctx, "SELECT * FROM users")
+		Expect(err).ToNot(HaveOccurred())
+		return rows
+	}
+
+	Describe("ScanOne", func() {
+		It("should scan the first row into a struct", func() {
+			var user scanUser
+			Expect(sqlf.ScanOne(query(), &user)).To(Succeed())
+			Expect(user.ID).To(Equal(int64(1)))
+			Expect(user.Name).To(Equal("john"))
+			Expect(user.Email).To(Equal(sql.NullString{String: "john@example.com", Valid: true}))
+			Expect(*user.Nickname).To(Equal("johnny"))
+			Expect(user.Age).To(Equal(30))
+			Expect(user.CreatedAt).To(Equal(createdAt))
+			Expect(user.scanAudit.Name).To(BeEmpty(), "the embedded field should be shadowed")
+			Expect(*user.Profile.Bio).To(Equal("hi"))
+		})
+
+		It("should return sql.ErrNoRows when there is no row", func() {
+			fd.Rows = nil
+			Expect(sqlf.ScanOne(query(), &scanUser{})).To(MatchError(sql.ErrNoRows))
+		})
+
+		It("should fail for columns without a field", func() {
+			fd.Columns = []string{"id", "unknown"}
+			err := sqlf.ScanOne(query(), &scanUser{})
+			Expect(err).To(MatchError(`sqlf: the column "unknown" has no field in sqlf_test.scanUser`))
+			Expect(errors.Is(err, sqlf.ErrUnmappedColumn)).To(BeTrue())
+
+			var unmapped *sqlf.UnmappedColumnError
+			Expect(errors.As(err, &unmapped)).To(BeTrue())
+			Expect(unmapped.Column).To(Equal("unknown"))
+		})
+
+		It("should fail for invalid destinations", func() {
+			var user scanUser
+			Expect(errors.Is(sqlf.ScanOne(query(), user), sqlf.ErrInvalidScanDestination)).To(BeTrue())
+			Expect(errors.Is(sqlf.ScanOne(query(), new(int)), sqlf.ErrInvalidScanDestination)).To(BeTrue())
+		})
+
+		It("should run and scan a select", func() {
+			var user scanUser
+			err := new(sqlf.SelectStatement).From("users").Where("id = ?", 1).RunWith(db).ScanOne(ctx, &user)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(user.Name).To(Equal("john"))
+			Expect(fd.LastQuery().SQL).To(Equal("SELECT * FROM users WHERE id = ?"))
+		})
+	})
+
+	Describe("ScanAll", func() {
+		It("should scan all rows into a slice of structs", func() {
+			users := []scanUser{{Name: "replaced"}}
+			Expect(sqlf.ScanAll(query(), &users)).To(Succeed())
+			Expect(users).To(HaveLen(2))
+			Expect(users[0].Name).To(Equal("john"))
+			Expect(users[1].Name).To(Equal("jane"))
+			Expect(users[1].Email.Valid).To(BeFalse())
+			Expect(users[1].Nickname).To(BeNil())
+			Expect(users[1].Profile).ToNot(BeNil())
+			Expect(users[1].Bio).To(BeNil())
+		})
+
+		It("should scan all rows into a slice of pointers", func() {
+			var users []*scanUser
+			Expect(sqlf.ScanAll(query(), &users)).To(Succeed())
+			Expect(users).To(HaveLen(2))
+			Expect(users[1].ID).To(Equal(int64(2)))
+		})
+
+		It("should return an empty slice when there is no row", func() {
+			fd.Rows = nil
+			users := []scanUser{{}}
+			Expect(sqlf.ScanAll(query(), &users)).To(Succeed())
+			Expect(users).To(BeEmpty())
+		})
+
+		It("should fail for invalid destinations", func() {
+			var ids []int64
+			Expect(errors.Is(sqlf.ScanAll(query(), &ids), sqlf.ErrInvalidScanDestination)).To(BeTrue())
+			Expect(errors.Is(sqlf.ScanAll(query(), &scanUser{}), sqlf.ErrInvalidScanDestination)).To(BeTrue())
+		})
+
+		It("should run and scan a select", func() {
+			var users []scanUser
+			Expect(new(sqlf.SelectStatement).From("users").RunWith(db).ScanAll(ctx, &users)).To(Succeed())
+			Expect(users).To(HaveLen(2))
+		})
+
+		It("should return the error of the query", func() {
+			forcedErr := errors.New("forced error")
+			fd.Err = forcedErr
+			var users []scanUser
+			Expect(new(sqlf.SelectStatement).From("users").RunWith(db).ScanAll(ctx, &users)).To(MatchError(forcedErr))
+		})
+	})
+
+	Describe("ScanOneMap", func() {
+		It("should scan the first row into a map", func() {
+			fd.Columns = []string{"id", "name", "email"}
+			row, err := sqlf.ScanOneMap(query())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(row).To(Equal(map[string]interface{}{
+				"id":    int64(1),
+				"name":  "john",
+				"email": "john@example.com",
+			}))
+		})
+
+		It("should return sql.ErrNoRows when there is no row", func() {
+			fd.Rows = nil
+			_, err := sqlf.ScanOneMap(query())
+			Expect(err).To(MatchError(sql.ErrNoRows))
+		})
+	})
+
+	Describe("ScanAllMaps", func() {
+		It("should scan all rows into maps", func() {
+			fd.Columns = []string{"id", "name", "email"}
+			rows, err := sqlf.ScanAllMaps(query())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rows).To(Equal([]map[string]interface{}{
+				{"id": int64(1), "name": "john", "email": "john@example.com"},
+				{"id": int64(2), "name": "jane", "email": nil},
+			}))
+		})
+	})
+})
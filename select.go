@@ -1,5 +1,7 @@
 package sqlf
 
+import "context"
+
 // Join represents a SQL Join.
 type Join interface {
 	FastSqlizer
@@ -46,6 +48,8 @@ type OrderBy interface {
 type Select interface {
 	FastSqlizer
 	Sqlizer
+	InterpolatedSqlizer
+	Runner
 
 	// Select defines the fields that will be returned by the query.
 	Select(fields ...interface{}) Select
@@ -106,8 +110,51 @@ type Select interface {
 	// Usually it will be automatically defined by the `Builder`.
 	Placeholder(placeholder PlaceholderFormatFactory) Select
 
+	// QuoteIdentifiers enables quoting every table and alias of the select automatically, using `quoter`. The
+	// `quoter` is also used for the `Identifier`s and aliases (`As`) rendered as part of the select.
+	QuoteIdentifiers(quoter IdentifierQuoter) Select
+
+	// Dialect defines the database technology the select is rendered for. The dialect provides the placeholder
+	// format (when `Placeholder` is not defined), the identifier quoting and the syntax of the features that differ
+	// between databases. Using a feature the dialect does not support returns an `UnsupportedFeatureError`.
+	Dialect(dialect Dialect) Select
+
+	// Strict enables the strict mode: fields, columns and expressions must be strings, `[]byte`s or `FastSqlizer`s
+	// (an `UnsupportedTypeError` is returned otherwise), raw SQL cannot contain statement terminators or comments,
+	// and table names and aliases must be plain identifiers (unless `QuoteIdentifiers` is enabled). Any violation
+	// returns an `UnsafeSQLError`.
+	//
+	// The strict mode also applies to the statements nested into this select.
+	Strict() Select
+
+	// EmptySlices defines how the empty slice arguments of the conditions are rendered: replacing their `IN` predicate
+	// (`EmptySlicePredicate`, the default), as `NULL` (`EmptySliceNull`) or failing the rendering (`EmptySliceError`).
+	// Check `Condition`.
+	//
+	// The style also applies to the statements nested into this select, unless they define their own.
+	EmptySlices(style EmptySliceStyle) Select
+
+	// Bind defines the values of the named parameters (Ex: `:since`) of the conditions, so the select can be
+	// defined once and bound per request. `values` is a map with string keys or a struct, whose fields are named
+	// by their `db` tag (falling back to the field name).
+	//
+	// The bindings also apply to the statements nested into this select, unless they define their own.
+	Bind(values interface{}) Select
+
+	// RunWith defines the `Executor` (Ex: `*sql.DB`, `*sql.Tx` or `*sql.Conn`) used by `ExecContext`,
+	// `QueryContext` and `QueryRowContext` to run the select.
+	RunWith(db Executor) Select
+
+	// ScanOne runs the select with its `Executor` and scans the first row into the struct pointed by `dest`. Check
+	// the `ScanOne` function.
+	ScanOne(ctx context.Context, dest interface{}) error
+
+	// ScanAll runs the select with its `Executor` and scans all rows into the slice pointed by `dest`. Check the
+	// `ScanAll` function.
+	ScanAll(ctx context.Context, dest interface{}) error
+
 	// CountQuery copies the current `Select` replacing all fields by `count`. If no `count` is given, it uses
-	// `COUNT(*)` as default.
+	// `COUNT(*)` as default. `count` accepts any field, including `Function`s (like `CountDistinct("email")`).
 	//
 	// The returned `Select` also has their `Limit` and `Offset` reset to none.
 	CountQuery(count ...interface{}) Select
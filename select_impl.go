@@ -1,29 +1,35 @@
 package sqlf
 
 import (
-	"strings"
+	"context"
+	"database/sql"
 )
 
 var (
-	sqlComma                   = []byte(", ")
-	sqlConditionAnd            = []byte(" AND ")
-	sqlSelectClause            = []byte("SELECT ")
-	sqlSelectAllFieldsClause   = []byte("*")
-	sqlSelectDistinctClause    = []byte("DISTINCT ")
-	sqlSelectFromClause        = []byte(" FROM ")
-	sqlSelectAsClause          = []byte(" AS ")
-	sqlSelectJoinClause        = []byte(" JOIN ")
-	sqlSelectJoinOnClause      = []byte(" ON ")
-	sqlSelectJoinUsingClause   = []byte(" USING ")
-	sqlWhereClause             = []byte(" WHERE ")
-	sqlSelectGroupByClause     = []byte(" GROUP BY ")
-	sqlSelectHavingClause      = []byte(" HAVING ")
-	sqlSelectOrderByClause     = []byte(" ORDER BY ")
-	sqlSelectOrderByDescClause = []byte(" DESC")
-	sqlSelectLimitClause       = []byte(" LIMIT ")
-	sqlSelectOffsetClause      = []byte(" OFFSET ")
-	sqlBracketOpen             = []byte("(")
-	sqlBracketClose            = []byte(")")
+	sqlComma                     = []byte(", ")
+	sqlConditionAnd              = []byte(" AND ")
+	sqlSelectClause              = []byte("SELECT ")
+	sqlSelectAllFieldsClause     = []byte("*")
+	sqlSelectDistinctClause      = []byte("DISTINCT ")
+	sqlSelectFromClause          = []byte(" FROM ")
+	sqlSelectAsClause            = []byte(" AS ")
+	sqlSelectJoinClause          = []byte(" JOIN ")
+	sqlSelectJoinOnClause        = []byte(" ON ")
+	sqlSelectJoinUsingClause     = []byte(" USING ")
+	sqlWhereClause               = []byte(" WHERE ")
+	sqlSelectGroupByClause       = []byte(" GROUP BY ")
+	sqlSelectHavingClause        = []byte(" HAVING ")
+	sqlSelectOrderByClause       = []byte(" ORDER BY ")
+	sqlSelectOrderByDescClause   = []byte(" DESC")
+	sqlSelectLimitClause         = []byte(" LIMIT ")
+	sqlSelectOffsetClause        = []byte(" OFFSET ")
+	sqlSelectOffsetZero          = []byte("0")
+	sqlSelectOffsetRowsClause    = []byte(" ROWS")
+	sqlSelectFetchNextClause     = []byte(" FETCH NEXT ")
+	sqlSelectFetchRowsOnlyClause = []byte(" ROWS ONLY")
+	sqlSelectOrderByNothing      = []byte(" ORDER BY (SELECT NULL)")
+	sqlBracketOpen               = []byte("(")
+	sqlBracketClose              = []byte(")")
 )
 
 type SelectStatement struct {
@@ -38,6 +44,13 @@ type SelectStatement struct {
 	limit             interface{}
 	offset            interface{}
 	placeholderFormat PlaceholderFormatFactory
+	quoter            IdentifierQuoter
+	dialect           Dialect
+	strict            bool
+	emptySlices       EmptySliceStyle
+	bindings          *bindings
+	registry          *Registry
+	executor          Executor
 }
 
 // Select defines the fields that will be returned by the query.
@@ -198,14 +211,102 @@ func (s *SelectStatement) Placeholder(placeholder PlaceholderFormatFactory) Sele
 	return s
 }
 
+// QuoteIdentifiers enables quoting every table and alias of the select automatically, using `quoter`. The
+// `quoter` is also used for the `Identifier`s and aliases (`As`) rendered as part of the select.
+func (s *SelectStatement) QuoteIdentifiers(quoter IdentifierQuoter) Select {
+	s.quoter = quoter
+	return s
+}
+
+// Dialect defines the database technology the select is rendered for. The dialect provides the placeholder
+// format (when `Placeholder` is not defined), the identifier quoting and the syntax of the features that differ
+// between databases. Using a feature the dialect does not support returns an `UnsupportedFeatureError`.
+func (s *SelectStatement) Dialect(dialect Dialect) Select {
+	s.dialect = dialect
+	return s
+}
+
+// Strict enables the strict mode: fields, columns and expressions must be strings, `[]byte`s or `FastSqlizer`s
+// (an `UnsupportedTypeError` is returned otherwise), raw SQL cannot contain statement terminators or comments,
+// and table names and aliases must be plain identifiers (unless `QuoteIdentifiers` is enabled). Any violation
+// returns an `UnsafeSQLError`.
+//
+// The strict mode also applies to the statements nested into this select.
+func (s *SelectStatement) Strict() Select {
+	s.strict = true
+	return s
+}
+
+// EmptySlices defines how the empty slice arguments of the conditions are rendered: replacing their `IN` predicate
+// (`EmptySlicePredicate`, the default), as `NULL` (`EmptySliceNull`) or failing the rendering (`EmptySliceError`).
+// Check `Condition`.
+//
+// The style also applies to the statements nested into this select, unless they define their own.
+func (s *SelectStatement) EmptySlices(style EmptySliceStyle) Select {
+	s.emptySlices = style
+	return s
+}
+
+// Bind defines the values of the named parameters (Ex: `:since`) of the conditions, so the select can be defined
+// once and bound per request. `values` is a map with string keys or a struct, whose fields are named by their `db`
+// tag (falling back to the field name).
+//
+// The bindings also apply to the statements nested into this select, unless they define their own.
+func (s *SelectStatement) Bind(values interface{}) Select {
+	s.bindings = newBindings(values)
+	return s
+}
+
+// RunWith defines the `Executor` (Ex: `*sql.DB`, `*sql.Tx` or `*sql.Conn`) used by `ExecContext`,
+// `QueryContext` and `QueryRowContext` to run the select.
+func (s *SelectStatement) RunWith(db Executor) Select {
+	s.executor = db
+	return s
+}
+
+// ExecContext executes the select with its `Executor`, without returning any rows.
+func (s *SelectStatement) ExecContext(ctx context.Context) (sql.Result, error) {
+	return execContext(ctx, s.executor, s)
+}
+
+// QueryContext executes the select with its `Executor`, returning its rows.
+func (s *SelectStatement) QueryContext(ctx context.Context) (*sql.Rows, error) {
+	return queryContext(ctx, s.executor, s)
+}
+
+// QueryRowContext executes the select with its `Executor`, returning at most one row. Check `Row`.
+func (s *SelectStatement) QueryRowContext(ctx context.Context) *Row {
+	return queryRowContext(ctx, s.executor, s)
+}
+
+// ScanOne runs the select with its `Executor` and scans the first row into the struct pointed by `dest`. Check the
+// `ScanOne` function.
+func (s *SelectStatement) ScanOne(ctx context.Context, dest interface{}) error {
+	rows, err := s.QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	return ScanOne(rows, dest)
+}
+
+// ScanAll runs the select with its `Executor` and scans all rows into the slice pointed by `dest`. Check the
+// `ScanAll` function.
+func (s *SelectStatement) ScanAll(ctx context.Context, dest interface{}) error {
+	rows, err := s.QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	return ScanAll(rows, dest)
+}
+
 // CountQuery copies the current `Select` replacing all fields by `count`. If no `count` is given, it uses
-// `COUNT(*)` as default.g
+// `COUNT(*)` as default. `count` accepts any field, including `Function`s (like `CountDistinct("email")`).
 //
 // The returned `Select` also has their `Limit` and `Offset` reset to none.
 func (s *SelectStatement) CountQuery(count ...interface{}) Select {
 	countQ := *s
 	if len(count) == 0 {
-		countQ.Select("COUNT(*)")
+		countQ.Select(Count())
 	} else {
 		countQ.Select(count...)
 	}
@@ -214,7 +315,7 @@ func (s *SelectStatement) CountQuery(count ...interface{}) Select {
 
 // ToSQL generates the SQL and returns it, alongside its params.
 func (s *SelectStatement) ToSQL() (string, []interface{}, error) {
-	var sb SQLWriter = new(strings.Builder)
+	sb := new(renderBuilder)
 	args := make([]interface{}, 0)
 	err := s.ToSQLFast(sb, &args)
 	if err != nil {
@@ -223,12 +324,37 @@ func (s *SelectStatement) ToSQL() (string, []interface{}, error) {
 	return sb.String(), args, nil
 }
 
+// ToInterpolatedSQL generates the SQL with its params inlined as literals, for logging and debugging. Check
+// `InterpolatedSQL`.
+func (s *SelectStatement) ToInterpolatedSQL() (InterpolatedSQL, error) {
+	return ToInterpolatedSQL(s)
+}
+
+// statementDialect returns the dialect of the select, used to write the literals of `ToInterpolatedSQL`.
+func (s *SelectStatement) statementDialect() Dialect {
+	return s.dialect
+}
+
 // ToSQLFast generates the SQL and returns it, alongside its params.
 func (s *SelectStatement) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
-	if s.placeholderFormat != nil {
-		sb = s.placeholderFormat.Wrap(sb)
-	}
+	session := beginRender(sb, args, s.placeholderFormat, renderContext{
+		dialect:     s.dialect,
+		quoter:      s.quoter,
+		quoteAll:    s.quoter != nil,
+		strict:      s.strict,
+		emptySlices: s.emptySlices,
+		bindings:    s.bindings,
+		registry:    s.registry,
+	})
+	return session.end(s.render(session.sb, args))
+}
 
+// render writes the select into the already prepared `sb`.
+func (s *SelectStatement) render(sb SQLWriter, args *[]interface{}) error {
+	err := s.bindings.bindError()
+	if err != nil {
+		return renderError("SELECT", "BIND", nil, err)
+	}
 	sb.Write(sqlSelectClause)
 	if s.distinct {
 		sb.Write(sqlSelectDistinctClause)
@@ -242,57 +368,62 @@ func (s *SelectStatement) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
 			}
 			err := RenderInterfaceAsSQL(sb, args, field)
 			if err != nil {
-				return err
+				return renderError("SELECT", clauseAt("FIELDS", idx), field, err)
 			}
 		}
 	}
 	sb.Write(sqlSelectFromClause)
-	sb.WriteString(s.table)
+	err = writeTable(sb, s.table)
+	if err != nil {
+		return renderError("SELECT", "FROM", s.table, err)
+	}
 	if s.as != "" {
 		sb.Write(sqlSelectAsClause)
-		sb.WriteString(s.as)
+		err := writeAlias(sb, s.as)
+		if err != nil {
+			return renderError("SELECT", "AS", s.as, err)
+		}
 	}
 
-	for _, join := range s.joins {
+	for idx, join := range s.joins {
 		sb.Write(sqlSpace)
 		err := join.ToSQLFast(sb, args)
 		if err != nil {
-			return err
+			return renderError("SELECT", clauseAt("JOIN", idx), join, err)
 		}
 	}
 
 	if len(s.where) > 0 {
 		sb.Write(sqlWhereClause)
-		for idx, condition := range s.where {
-			if idx > 0 {
-				sb.Write(sqlConditionAnd)
-			}
-			err := RenderInterfaceAsSQL(sb, args, condition)
-			if err != nil {
-				return err
-			}
+		err := writeConditions(sb, args, "SELECT", "WHERE", s.where)
+		if err != nil {
+			return err
 		}
 	}
 
 	if s.groupBy != nil {
 		err := s.groupBy.ToSQLFast(sb, args)
 		if err != nil {
-			return err
+			return renderError("SELECT", "GROUP BY", s.groupBy, err)
 		}
 	}
 
 	if s.orderBy != nil {
 		err := s.orderBy.ToSQLFast(sb, args)
 		if err != nil {
-			return err
+			return renderError("SELECT", "ORDER BY", s.orderBy, err)
 		}
 	}
 
+	if dialect := dialectOf(sb); dialect != nil && dialect.Pagination() == PaginationOffsetFetch {
+		return s.writeOffsetFetch(sb, args)
+	}
+
 	if s.limit != nil {
 		sb.Write(sqlSelectLimitClause)
 		err := RenderInterfaceAsArg(sb, args, s.limit)
 		if err != nil {
-			return err
+			return renderError("SELECT", "LIMIT", s.limit, err)
 		}
 	}
 
@@ -300,9 +431,39 @@ func (s *SelectStatement) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
 		sb.Write(sqlSelectOffsetClause)
 		err := RenderInterfaceAsArg(sb, args, s.offset)
 		if err != nil {
-			return err
+			return renderError("SELECT", "OFFSET", s.offset, err)
 		}
 	}
 
 	return nil
 }
+
+// writeOffsetFetch writes the pagination as `OFFSET ? ROWS FETCH NEXT ? ROWS ONLY`. As this syntax requires an
+// ORDER BY, `ORDER BY (SELECT NULL)` is added when the select has none.
+func (s *SelectStatement) writeOffsetFetch(sb SQLWriter, args *[]interface{}) error {
+	if s.limit == nil && s.offset == nil {
+		return nil
+	}
+	if s.orderBy == nil {
+		sb.Write(sqlSelectOrderByNothing)
+	}
+	sb.Write(sqlSelectOffsetClause)
+	if s.offset == nil {
+		sb.Write(sqlSelectOffsetZero)
+	} else {
+		err := RenderInterfaceAsArg(sb, args, s.offset)
+		if err != nil {
+			return renderError("SELECT", "OFFSET", s.offset, err)
+		}
+	}
+	sb.Write(sqlSelectOffsetRowsClause)
+	if s.limit != nil {
+		sb.Write(sqlSelectFetchNextClause)
+		err := RenderInterfaceAsArg(sb, args, s.limit)
+		if err != nil {
+			return renderError("SELECT", "LIMIT", s.limit, err)
+		}
+		sb.Write(sqlSelectFetchRowsOnlyClause)
+	}
+	return nil
+}
@@ -85,7 +85,7 @@ var _ = Describe("Select", func() {
 			Expect(args).To(BeNil())
 			Expect(sql).To(BeEmpty())
 			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(Equal("forced error"))
+			Expect(err).To(MatchError("sqlf: SELECT FIELDS #1: forced error"))
 		})
 	})
 
@@ -134,7 +134,7 @@ var _ = Describe("Select", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(args).To(BeNil())
 			Expect(sql).To(BeEmpty())
-			Expect(err.Error()).To(Equal("forced error"))
+			Expect(err).To(MatchError("sqlf: SELECT JOIN #1 > ON #1: forced error"))
 		})
 
 		It("should generate a INNER JOIN", func() {
@@ -204,7 +204,7 @@ var _ = Describe("Select", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(args).To(BeNil())
 			Expect(sql).To(BeEmpty())
-			Expect(err.Error()).To(Equal("forced error"))
+			Expect(err).To(MatchError("sqlf: SELECT WHERE #1: forced error"))
 		})
 	})
 
@@ -258,7 +258,7 @@ var _ = Describe("Select", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(args).To(BeNil())
 			Expect(sql).To(BeEmpty())
-			Expect(err.Error()).To(Equal("forced error"))
+			Expect(err).To(MatchError("sqlf: SELECT GROUP BY: forced error"))
 		})
 
 		It("should reset GROUP BY X", func() {
@@ -313,7 +313,7 @@ var _ = Describe("Select", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(args).To(BeNil())
 			Expect(sql).To(BeEmpty())
-			Expect(err.Error()).To(Equal("forced error"))
+			Expect(err).To(MatchError("sqlf: SELECT ORDER BY: forced error"))
 		})
 
 		It("should generate with a ORDER BY desc", func() {
@@ -349,7 +349,7 @@ var _ = Describe("Select", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(args).To(BeNil())
 			Expect(sql).To(BeEmpty())
-			Expect(err.Error()).To(Equal("forced error"))
+			Expect(err).To(MatchError("sqlf: SELECT LIMIT: forced error"))
 		})
 
 		It("should generate with a LIMIT and OFFSET clause", func() {
@@ -378,7 +378,7 @@ var _ = Describe("Select", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(args).To(BeNil())
 			Expect(sql).To(BeEmpty())
-			Expect(err.Error()).To(Equal("forced error"))
+			Expect(err).To(MatchError("sqlf: SELECT OFFSET: forced error"))
 		})
 
 		It("should generate with a LIMIT and OFFSET clause `Limit` and `Offset`", func() {
@@ -436,5 +436,15 @@ var _ = Describe("Select", func() {
 			Expect(args).To(BeEmpty())
 			Expect(sql).To(Equal("SELECT name, email FROM users"))
 		})
+
+		It("should generate a count query with a function", func() {
+			s := new(sqlf.SelectStatement).Select("name", "email").From("users")
+
+			sCount := s.CountQuery(sqlf.CountDistinct("email"))
+			sqlCount, argsCount, errCount := sCount.ToSQL()
+			Expect(errCount).ToNot(HaveOccurred())
+			Expect(argsCount).To(BeEmpty())
+			Expect(sqlCount).To(Equal("SELECT COUNT(DISTINCT email) FROM users"))
+		})
 	})
 })
@@ -21,6 +21,12 @@ type Sqlizer interface {
 	ToSQL() (string, []interface{}, error)
 }
 
+// InterpolatedSqlizer define anything that outputs a SQL with its params inlined, for logging and debugging.
+type InterpolatedSqlizer interface {
+	// ToInterpolatedSQL generates the SQL with its params inlined as literals. The result is unsafe to execute.
+	ToInterpolatedSQL() (InterpolatedSQL, error)
+}
+
 // FastSqlizer define anything that outputs a SQL.
 type FastSqlizer interface {
 	// ToSQLFast generates the SQL and returns it, alongside its params.
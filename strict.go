@@ -0,0 +1,136 @@
+package sqlf
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var (
+	// ErrUnsupportedType is the error matched, through `errors.Is`, by any `UnsupportedTypeError`.
+	ErrUnsupportedType = errors.New("unsupported type")
+
+	// ErrUnsafeSQL is the error matched, through `errors.Is`, by any `UnsafeSQLError`.
+	ErrUnsafeSQL = errors.New("unsafe SQL")
+
+	// unsafeSQLTokens are refused in raw SQL by the strict mode: statement terminators and comments.
+	unsafeSQLTokens = []string{";", "--", "/*"}
+)
+
+// UnsupportedTypeError is returned, in strict mode, when a value that cannot be safely rendered as SQL is used as a
+// field, column or expression.
+type UnsupportedTypeError struct {
+	Type reflect.Type
+}
+
+// Error implements the `error` interface.
+func (err *UnsupportedTypeError) Error() string {
+	return fmt.Sprintf("sqlf: strict mode cannot render %v as SQL, use a string, an Identifier or a FastSqlizer", err.Type)
+}
+
+// Is makes `errors.Is(err, ErrUnsupportedType)` match.
+func (err *UnsupportedTypeError) Is(target error) bool {
+	return target == ErrUnsupportedType
+}
+
+// UnsafeSQLError is returned, in strict mode, when a raw SQL contains a statement terminator or a comment, or when
+// a table name is not a plain identifier.
+type UnsafeSQLError struct {
+	SQL    string
+	Reason string
+}
+
+// Error implements the `error` interface.
+func (err *UnsafeSQLError) Error() string {
+	return fmt.Sprintf("sqlf: strict mode refused %q: %s", err.SQL, err.Reason)
+}
+
+// Is makes `errors.Is(err, ErrUnsafeSQL)` match.
+func (err *UnsafeSQLError) Is(target error) bool {
+	return target == ErrUnsafeSQL
+}
+
+// isStrict reports whether the statement being rendered is in strict mode.
+func isStrict(sb SQLWriter) bool {
+	ctx, ok := contextOf(sb)
+	return ok && ctx.strict
+}
+
+// checkRawSQL returns an `UnsafeSQLError` if the statement being rendered is in strict mode and `sql` contains a
+// statement terminator or a comment.
+func checkRawSQL(sb SQLWriter, sql string) error {
+	if !isStrict(sb) {
+		return nil
+	}
+	for _, token := range unsafeSQLTokens {
+		if strings.Contains(sql, token) {
+			return &UnsafeSQLError{
+				SQL:    sql,
+				Reason: fmt.Sprintf("raw SQL cannot contain %q, bind values as arguments instead", token),
+			}
+		}
+	}
+	return nil
+}
+
+// checkName returns an `UnsafeSQLError` if the statement being rendered is in strict mode and `name` (a table
+// name or an alias) is not a plain, possibly qualified, identifier.
+func checkName(sb SQLWriter, name string) error {
+	if !isStrict(sb) {
+		return nil
+	}
+	for _, part := range strings.Split(name, ".") {
+		if !isPlainIdentifier(part) {
+			return &UnsafeSQLError{
+				SQL:    name,
+				Reason: "not a valid identifier, enable QuoteIdentifiers for dynamic names",
+			}
+		}
+	}
+	return nil
+}
+
+// isPlainIdentifier reports whether `name` is an identifier that does not need quotes: a letter or `_` followed
+// by letters, digits, `_` or `$`. Non ASCII characters are accepted as letters.
+func isPlainIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '_', c >= 0x80:
+		case i > 0 && (c >= '0' && c <= '9' || c == '$'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// renderStrictSQL renders `element` as SQL following the strict mode rules: only strings, `[]byte`s free of
+// terminators and comments, and `FastSqlizer`s are accepted.
+func renderStrictSQL(sb SQLWriter, args *[]interface{}, element interface{}) error {
+	switch p := element.(type) {
+	case string:
+		err := checkRawSQL(sb, p)
+		if err != nil {
+			return err
+		}
+		sb.WriteString(p)
+	case []byte:
+		err := checkRawSQL(sb, string(p))
+		if err != nil {
+			return err
+		}
+		sb.Write(p)
+	case FastSqlizer:
+		return p.ToSQLFast(sb, args)
+	default:
+		return &UnsupportedTypeError{
+			Type: reflect.TypeOf(element),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package sqlf_test
+
+import (
+	"errors"
+	"reflect"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/jamillosantos/sqlf"
+)
+
+var _ = Describe("Strict", func() {
+	It("should render a safe select", func() {
+		sql, args, err := sqlf.NewBuilder().
+			Strict().
+			Select().
+			Select("u.id", sqlf.Ident("name"), sqlf.Count()).
+			From("public.users", "u").
+			LeftJoin("orders", "o").On("o.user_id = u.id").
+			Where("u.active = ?", true).
+			GroupBy("u.id").
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(Equal([]interface{}{true}))
+		Expect(sql).To(Equal(`SELECT u.id, "name", COUNT(*) FROM public.users AS u LEFT JOIN orders AS o ON o.user_id = u.id WHERE u.active = ? GROUP BY u.id`))
+	})
+
+	It("should not affect statements that are not strict", func() {
+		sql, _, err := new(sqlf.SelectStatement).Select(1).From("users; --").ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("SELECT 1 FROM users; --"))
+	})
+
+	It("should fail rendering an unsupported field type", func() {
+		_, _, err := new(sqlf.SelectStatement).Strict().Select("id", 1).From("users").ToSQL()
+		Expect(errors.Is(err, sqlf.ErrUnsupportedType)).To(BeTrue())
+		var typeErr *sqlf.UnsupportedTypeError
+		Expect(errors.As(err, &typeErr)).To(BeTrue())
+		Expect(typeErr.Type).To(Equal(reflect.TypeOf(1)))
+	})
+
+	DescribeTable("unsafe SQL",
+		func(build func() sqlf.Sqlizer) {
+			_, _, err := build().ToSQL()
+			Expect(errors.Is(err, sqlf.ErrUnsafeSQL)).To(BeTrue(), "%v", err)
+		},
+		Entry("terminator in a field", func() sqlf.Sqlizer {
+			return new(sqlf.SelectStatement).Strict().Select("id; DROP TABLE users").From("users")
+		}),
+		Entry("comment in a condition", func() sqlf.Sqlizer {
+			return new(sqlf.SelectStatement).Strict().From("users").Where("id = 1 --")
+		}),
+		Entry("block comment in a column", func() sqlf.Sqlizer {
+			return new(sqlf.SelectStatement).Strict().From("users").WhereCriteria(sqlf.Eq("id /* x */", 1))
+		}),
+		Entry("dynamic table name", func() sqlf.Sqlizer {
+			return new(sqlf.SelectStatement).Strict().From("users u")
+		}),
+		Entry("dynamic alias", func() sqlf.Sqlizer {
+			return new(sqlf.SelectStatement).Strict().From("users", `u"`)
+		}),
+		Entry("dynamic join table", func() sqlf.Sqlizer {
+			return new(sqlf.SelectStatement).Strict().From("users").InnerJoin("orders o").On("true")
+		}),
+		Entry("update table", func() sqlf.Sqlizer {
+			return new(sqlf.UpdateStatement).Strict().Table("users(").Set("name", "a")
+		}),
+		Entry("insert table", func() sqlf.Sqlizer {
+			return new(sqlf.InsertStatement).Strict().Into("1users", "name").Values("a")
+		}),
+		Entry("delete suffix", func() sqlf.Sqlizer {
+			return new(sqlf.DeleteStatement).Strict().From("users").Suffix("; DELETE FROM orders")
+		}),
+		Entry("nested statement", func() sqlf.Sqlizer {
+			return new(sqlf.SelectStatement).Strict().From("users").WhereCriteria(sqlf.Exists(new(sqlf.SelectStatement).From("orders").Where("1=1;")))
+		}),
+	)
+
+	It("should accept dynamic names when identifiers are quoted", func() {
+		sql, _, err := new(sqlf.SelectStatement).
+			Strict().
+			QuoteIdentifiers(sqlf.DoubleQuoteIdentifier).
+			From("user table", `a"b`).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal(`SELECT * FROM "user table" AS "a""b"`))
+	})
+})
@@ -0,0 +1,223 @@
+package sqlf
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// structField is a field of a struct mapped to a column.
+type structField struct {
+	// name is the column of the field: its `db` tag, falling back to the field name.
+	name string
+	// index is the path of the field, through the embedded structs, as used by `reflect.Value.FieldByIndex`.
+	index []int
+	// omitEmpty, readOnly and primaryKey are the `omitempty`, `readonly` and `pk` options of the `db` tag. Check
+	// `StructOption`.
+	omitEmpty  bool
+	readOnly   bool
+	primaryKey bool
+}
+
+// structMetadata is the reflection metadata of a struct type, computed once per type (check `structMetadataOf`).
+type structMetadata struct {
+	fields []structField
+	byName map[string]int
+}
+
+// field returns the field mapped to the column `name`.
+func (m *structMetadata) field(name string) (structField, bool) {
+	i, ok := m.byName[name]
+	if !ok {
+		return structField{}, false
+	}
+	return m.fields[i], true
+}
+
+// structMetadataCache caches the `structMetadata` by `reflect.Type`.
+var structMetadataCache sync.Map
+
+// structMetadataOf returns the metadata of the struct type `t`.
+//
+// Fields are named by their `db` tag, falling back to the field name. Fields tagged with `db:"-"` and unexported
+// fields are ignored. Embedded structs (or pointers to them) without a tag are flattened, as encoding/json does:
+// their fields are shadowed by the fields with the same name closer to the outer struct, and the fields with the
+// same name at the same depth are ignored, unless only one of them is tagged.
+func structMetadataOf(t reflect.Type) *structMetadata {
+	if m, ok := structMetadataCache.Load(t); ok {
+		return m.(*structMetadata)
+	}
+	m := &structMetadata{
+		fields: collectStructFields(t),
+		byName: make(map[string]int),
+	}
+	for i := range m.fields {
+		m.byName[m.fields[i].name] = i
+	}
+	actual, _ := structMetadataCache.LoadOrStore(t, m)
+	return actual.(*structMetadata)
+}
+
+// fieldCandidate is a field that may be mapped to a column, before the fields with the same name are resolved.
+type fieldCandidate struct {
+	field structField
+	// tagged is true when the name of the field comes from its `db` tag.
+	tagged bool
+}
+
+// embeddedStruct is an embedded struct type, found at the path `index`, whose fields are still to be collected.
+type embeddedStruct struct {
+	typ   reflect.Type
+	index []int
+}
+
+// collectStructFields returns the fields of the struct type `t`, in the order they are declared, flattening the
+// embedded structs level by level. An embedded struct type already flattened at a lower depth is skipped, so
+// types that embed themselves (Ex: `type Node struct{ *Node }`) are finite.
+func collectStructFields(t reflect.Type) []structField {
+	var (
+		fields  []structField
+		current []embeddedStruct
+		next    = []embeddedStruct{{typ: t}}
+		visited = make(map[reflect.Type]bool)
+		// resolved are the names of the fields found at a lower depth, which shadow the ones found deeper.
+		resolved = make(map[string]bool)
+	)
+	for len(next) > 0 {
+		current, next = next, current[:0]
+		candidates := make(map[string][]fieldCandidate)
+		var names []string
+		for _, embedded := range current {
+			if visited[embedded.typ] {
+				continue
+			}
+			visited[embedded.typ] = true
+			for i := 0; i < embedded.typ.NumField(); i++ {
+				field := embedded.typ.Field(i)
+				tag := field.Tag.Get("db")
+				if tag == "-" {
+					continue
+				}
+				index := make([]int, len(embedded.index)+1)
+				copy(index, embedded.index)
+				index[len(embedded.index)] = i
+
+				if field.Anonymous && tag == "" {
+					ft := field.Type
+					if ft.Kind() == reflect.Ptr && field.PkgPath == "" {
+						// Unexported embedded pointers cannot be allocated, so only the exported ones are followed.
+						ft = ft.Elem()
+					}
+					if ft.Kind() == reflect.Struct {
+						next = append(next, embeddedStruct{typ: ft, index: index})
+						continue
+					}
+				}
+				if field.PkgPath != "" {
+					// Unexported field.
+					continue
+				}
+				sf := parseStructField(field, tag)
+				sf.index = index
+				if resolved[sf.name] {
+					// Shadowed by a field closer to the outer struct.
+					continue
+				}
+				if _, ok := candidates[sf.name]; !ok {
+					names = append(names, sf.name)
+				}
+				candidates[sf.name] = append(candidates[sf.name], fieldCandidate{
+					field:  sf,
+					tagged: tag != "" && !strings.HasPrefix(tag, ","),
+				})
+			}
+		}
+		for _, name := range names {
+			resolved[name] = true
+			if field, ok := dominantField(candidates[name]); ok {
+				fields = append(fields, field)
+			}
+		}
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return lessIndex(fields[i].index, fields[j].index)
+	})
+	return fields
+}
+
+// dominantField returns the field, among the `candidates` with the same name at the same depth, that is mapped to
+// the column: the only one, or the only one that is tagged. It returns false when the name is ambiguous.
+func dominantField(candidates []fieldCandidate) (structField, bool) {
+	if len(candidates) == 1 {
+		return candidates[0].field, true
+	}
+	dominant := -1
+	for i, candidate := range candidates {
+		if !candidate.tagged {
+			continue
+		}
+		if dominant >= 0 {
+			return structField{}, false
+		}
+		dominant = i
+	}
+	if dominant < 0 {
+		return structField{}, false
+	}
+	return candidates[dominant].field, true
+}
+
+// lessIndex reports whether the field at the path `a` is declared before the one at `b`.
+func lessIndex(a, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// parseStructField parses the `db` tag of `field`: the column name, falling back to the field name, followed by
+// its options. Ex: `db:"id,pk"`.
+func parseStructField(field reflect.StructField, tag string) structField {
+	sf := structField{
+		name: field.Name,
+	}
+	if tag == "" {
+		return sf
+	}
+	options := strings.Split(tag, ",")
+	if options[0] != "" {
+		sf.name = options[0]
+	}
+	for _, option := range options[1:] {
+		switch strings.TrimSpace(option) {
+		case "omitempty":
+			sf.omitEmpty = true
+		case "readonly":
+			sf.readOnly = true
+		case "pk":
+			sf.primaryKey = true
+		}
+	}
+	return sf
+}
+
+// fieldByIndex returns the field of the struct `v` at the path `index`. Nil embedded pointers are allocated when
+// `alloc` is true; otherwise, false is returned when one is found.
+func fieldByIndex(v reflect.Value, index []int, alloc bool) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !alloc {
+					return reflect.Value{}, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
@@ -0,0 +1,260 @@
+package sqlf
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// ErrInvalidStruct is returned when the value given to `Insert.Struct`, `Insert.Structs` or `Update.SetStruct`
+	// is not a struct (or a slice of them), or it is not a pointer while `ReturningGenerated` is used.
+	ErrInvalidStruct = errors.New("invalid struct")
+
+	// ErrNoLastInsertID is returned by the `sql.Result.LastInsertId` of the statements that scanned their generated
+	// columns back into their structs (check `ReturningGenerated`). The generated ids are in the structs instead.
+	ErrNoLastInsertID = errors.New("the last insert id is not available when returning the generated columns")
+
+	// ErrReturningMismatch is returned when an insert scanning its generated columns back into its structs (check
+	// `ReturningGenerated`) returns a different number of rows than structs, as when conflicting rows are skipped.
+	ErrReturningMismatch = errors.New("the rows returned do not match the structs")
+)
+
+// StructOption changes how the columns of a struct are picked by `Insert.Struct`, `Insert.Structs` and
+// `Update.SetStruct`.
+//
+// The columns of a struct are its fields, named by their `db` tag (check `ScanOne`). The tag also accepts the
+// options below, after the name:
+//
+//	type User struct {
+//		ID        int64     `db:"id,pk"`
+//		Name      string    `db:"name"`
+//		Nickname  string    `db:"nickname,omitempty"`
+//		CreatedAt time.Time `db:"created_at,readonly"`
+//	}
+//
+// - `pk`: a primary key column. It is never updated, and it is not inserted while it has its zero value, so the
+// database generates it;
+// - `readonly`: a column generated by the database. It is never inserted nor updated;
+// - `omitempty`: the column is not inserted nor updated while it has its zero value.
+type StructOption func(*structOptions)
+
+// structOptions is the result of applying the `StructOption`s.
+type structOptions struct {
+	omitEmpty  bool
+	include    map[string]bool
+	exclude    map[string]bool
+	readOnly   map[string]bool
+	primaryKey map[string]bool
+	returning  bool
+}
+
+// OmitEmpty skips the columns that have their zero value, as if all of them were tagged with `omitempty`.
+func OmitEmpty() StructOption {
+	return func(opts *structOptions) {
+		opts.omitEmpty = true
+	}
+}
+
+// IncludeColumns picks only the given columns of the struct. The columns listed are picked even when they are
+// read-only or primary keys, but `omitempty` still applies to them.
+func IncludeColumns(columns ...string) StructOption {
+	return func(opts *structOptions) {
+		opts.include = addColumns(opts.include, columns)
+	}
+}
+
+// ExcludeColumns skips the given columns of the struct.
+func ExcludeColumns(columns ...string) StructOption {
+	return func(opts *structOptions) {
+		opts.exclude = addColumns(opts.exclude, columns)
+	}
+}
+
+// ReadOnlyColumns marks the given columns as generated by the database, as if they were tagged with `readonly`.
+func ReadOnlyColumns(columns ...string) StructOption {
+	return func(opts *structOptions) {
+		opts.readOnly = addColumns(opts.readOnly, columns)
+	}
+}
+
+// PrimaryKeyColumns marks the given columns as primary keys, as if they were tagged with `pk`.
+func PrimaryKeyColumns(columns ...string) StructOption {
+	return func(opts *structOptions) {
+		opts.primaryKey = addColumns(opts.primaryKey, columns)
+	}
+}
+
+// ReturningGenerated adds a RETURNING clause with the read-only and primary key columns that were not written by
+// the statement. When the statement is executed with `ExecContext`, the returned rows are scanned back into the
+// structs, in order, so they must be given as a pointer to a struct or as a slice. An insert must return one row
+// per struct, otherwise it fails with `ErrReturningMismatch`. Ex:
+//
+//	user := &User{Name: "john"}
+//	_, err := b.Insert("users").Struct(user, sqlf.ReturningGenerated()).ExecContext(ctx)
+//	// user.ID and user.CreatedAt are now defined.
+func ReturningGenerated() StructOption {
+	return func(opts *structOptions) {
+		opts.returning = true
+	}
+}
+
+// addColumns adds `columns` to the `set`, creating it when needed.
+func addColumns(set map[string]bool, columns []string) map[string]bool {
+	if set == nil {
+		set = make(map[string]bool, len(columns))
+	}
+	for _, column := range columns {
+		set[column] = true
+	}
+	return set
+}
+
+// newStructOptions applies the `opts`.
+func newStructOptions(opts []StructOption) *structOptions {
+	options := new(structOptions)
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+// generated reports whether the `field` is generated by the database: read-only or a primary key.
+func (opts *structOptions) generated(field *structField) bool {
+	return field.readOnly || opts.readOnly[field.name] || field.primaryKey || opts.primaryKey[field.name]
+}
+
+// picks reports whether the `field`, with the value `v`, is written by the statement. Primary keys are only
+// written by inserts.
+func (opts *structOptions) picks(field *structField, v reflect.Value, insert bool) bool {
+	if opts.exclude[field.name] {
+		return false
+	}
+	if len(opts.include) > 0 && !opts.include[field.name] {
+		return false
+	}
+	zero := !v.IsValid() || v.IsZero()
+	if (opts.omitEmpty || field.omitEmpty) && zero {
+		return false
+	}
+	if opts.include[field.name] {
+		return true
+	}
+	if field.readOnly || opts.readOnly[field.name] {
+		return false
+	}
+	if field.primaryKey || opts.primaryKey[field.name] {
+		return insert && !zero
+	}
+	return true
+}
+
+// structColumns is the result of picking the columns of one or more structs of the same type.
+type structColumns struct {
+	// columns are the names of the columns written by the statement.
+	columns []interface{}
+	// values are the values of the `columns`, for each struct, in order.
+	values []interface{}
+	// returning are the generated columns not written by the statement, when `ReturningGenerated` is used.
+	returning []interface{}
+	// targets are the structs that receive the `returning` columns.
+	targets []reflect.Value
+}
+
+// pickStructColumns picks the columns of the structs of `value`: a struct, a pointer to a struct, or a slice of
+// them (when `many` is true). All the structs must pick the same columns, as they share the column list of the
+// statement.
+func pickStructColumns(value interface{}, many, insert bool, opts []StructOption) (*structColumns, error) {
+	options := newStructOptions(opts)
+	structs, err := structValues(value, many, options.returning)
+	if err != nil {
+		return nil, err
+	}
+	result := new(structColumns)
+	if len(structs) == 0 {
+		return result, nil
+	}
+
+	metadata := structMetadataOf(structs[0].Type())
+	picked := make([]bool, len(metadata.fields))
+	for i := range metadata.fields {
+		field := &metadata.fields[i]
+		fv, _ := fieldByIndex(structs[0], field.index, false)
+		picked[i] = options.picks(field, fv, insert)
+		if picked[i] {
+			result.columns = append(result.columns, field.name)
+		} else if options.returning && options.generated(field) && !options.exclude[field.name] {
+			result.returning = append(result.returning, field.name)
+		}
+	}
+
+	for n, s := range structs {
+		for i := range metadata.fields {
+			field := &metadata.fields[i]
+			fv, ok := fieldByIndex(s, field.index, false)
+			if n > 0 && options.picks(field, fv, insert) != picked[i] {
+				return nil, fmt.Errorf("%w: the struct #%d does not pick the column %q as the first one does",
+					ErrInvalidStruct, n+1, field.name)
+			}
+			if !picked[i] {
+				continue
+			}
+			if !ok {
+				// A field of a nil embedded pointer.
+				result.values = append(result.values, nil)
+				continue
+			}
+			result.values = append(result.values, fv.Interface())
+		}
+	}
+	if len(result.returning) > 0 {
+		result.targets = structs
+	}
+	return result, nil
+}
+
+// structValues returns the struct values of `value`. They are addressable when `addressable` is true.
+func structValues(value interface{}, many, addressable bool) ([]reflect.Value, error) {
+	v := reflect.ValueOf(value)
+	if !many {
+		s, ok := structValue(v, addressable)
+		if !ok {
+			return nil, fmt.Errorf("%w: expected a struct or a pointer to a struct, got %T", ErrInvalidStruct, value)
+		}
+		return []reflect.Value{s}, nil
+	}
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("%w: expected a slice of structs, got %T", ErrInvalidStruct, value)
+	}
+	structs := make([]reflect.Value, v.Len())
+	for i := range structs {
+		s, ok := structValue(v.Index(i), addressable)
+		if !ok {
+			return nil, fmt.Errorf("%w: expected a slice of structs, got %T", ErrInvalidStruct, value)
+		}
+		if i > 0 && s.Type() != structs[0].Type() {
+			return nil, fmt.Errorf("%w: expected structs of the same type, got %s and %s", ErrInvalidStruct,
+				structs[0].Type(), s.Type())
+		}
+		structs[i] = s
+	}
+	return structs, nil
+}
+
+// structValue returns the struct of `v`, dereferencing it. The struct must be addressable when `addressable` is
+// true.
+func structValue(v reflect.Value, addressable bool) (reflect.Value, bool) {
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct || (addressable && !v.CanAddr()) {
+		return reflect.Value{}, false
+	}
+	return v, true
+}
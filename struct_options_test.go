@@ -0,0 +1,295 @@
+package sqlf_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/jamillosantos/sqlf"
+	"github.com/jamillosantos/sqlf/testingutils"
+)
+
+type structAccount struct {
+	ID        int64     `db:"id,pk"`
+	Name      string    `db:"name"`
+	Nickname  string    `db:"nickname,omitempty"`
+	Age       int       `db:"age"`
+	CreatedAt time.Time `db:"created_at,readonly"`
+	Secret    string    `db:"-"`
+}
+
+// structCredential has `[]byte` fields, which are bound as any other value.
+type structCredential struct {
+	ID     int64  `db:"id,pk"`
+	Hash   []byte `db:"hash"`
+	Avatar []byte `db:"avatar"`
+}
+
+// StructNode embeds a pointer to its own type.
+type StructNode struct {
+	ID int64 `db:"id"`
+	*StructNode
+}
+
+type structHome struct {
+	Address string `db:"address"`
+	Phone   string `db:"phone"`
+}
+
+type structWork struct {
+	Address string `db:"address"`
+	Phone   string
+	Company string `db:"Phone"`
+}
+
+// structContact has the ambiguous "address" column, and the "Phone" column tagged only by `structWork.Company`.
+type structContact struct {
+	Name string `db:"name"`
+	structHome
+	structWork
+}
+
+var _ = Describe("Struct", func() {
+	createdAt := time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC)
+
+	Describe("Insert", func() {
+		It("should insert the columns of a struct", func() {
+			sql, args, err := sqlf.NewBuilder().Insert("accounts").Struct(structAccount{Name: "john", Age: 30, Secret: "x"}).ToSQL()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sql).To(Equal("INSERT INTO accounts (name, age) VALUES (?,?)"))
+			Expect(args).To(Equal([]interface{}{"john", 30}))
+		})
+
+		It("should bind the []byte fields", func() {
+			sql, args, err := sqlf.NewBuilder().Insert("credentials").Struct(structCredential{Hash: []byte("'; DROP TABLE users; --")}).ToSQL()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sql).To(Equal("INSERT INTO credentials (hash, avatar) VALUES (?,?)"))
+			Expect(args).To(Equal([]interface{}{[]byte("'; DROP TABLE users; --"), []byte(nil)}))
+		})
+
+		It("should insert the primary key when it is defined", func() {
+			sql, args, err := sqlf.NewBuilder().Insert("accounts").Struct(&structAccount{ID: 7, Name: "john", Nickname: "johnny"}).ToSQL()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sql).To(Equal("INSERT INTO accounts (id, name, nickname, age) VALUES (?,?,?,?)"))
+			Expect(args).To(Equal([]interface{}{int64(7), "john", "johnny", 0}))
+		})
+
+		It("should insert a slice of structs", func() {
+			sql, args, err := sqlf.NewBuilder().Insert("accounts").Structs([]*structAccount{
+				{Name: "john", Nickname: "johnny"},
+				{Name: "jane", Nickname: "janie"},
+			}).ToSQL()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sql).To(Equal("INSERT INTO accounts (name, nickname, age) VALUES (?,?,?), (?,?,?)"))
+			Expect(args).To(Equal([]interface{}{"john", "johnny", 0, "jane", "janie", 0}))
+		})
+
+		It("should fail when the structs pick different columns", func() {
+			_, _, err := sqlf.NewBuilder().Insert("accounts").Structs([]*structAccount{
+				{Name: "john"},
+				{Name: "jane", Nickname: "janie"},
+			}).ToSQL()
+			Expect(err).To(MatchError(`sqlf: INSERT STRUCT: invalid struct: the struct #2 does not pick the column "nickname" as the first one does`))
+			Expect(errors.Is(err, sqlf.ErrInvalidStruct)).To(BeTrue())
+
+			_, _, err = sqlf.NewBuilder().Insert("accounts").Structs([]structAccount{{ID: 1, Name: "john"}, {Name: "jane"}}).ToSQL()
+			Expect(errors.Is(err, sqlf.ErrInvalidStruct)).To(BeTrue(), "the primary key is only inserted when defined")
+		})
+
+		It("should pick the columns by the options", func() {
+			account := structAccount{ID: 1, Name: "john", CreatedAt: createdAt}
+
+			sql, _, err := sqlf.NewBuilder().Insert("accounts").Struct(account, sqlf.OmitEmpty()).ToSQL()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sql).To(Equal("INSERT INTO accounts (id, name) VALUES (?,?)"))
+
+			sql, _, err = sqlf.NewBuilder().Insert("accounts").Struct(account, sqlf.IncludeColumns("name", "created_at")).ToSQL()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sql).To(Equal("INSERT INTO accounts (name, created_at) VALUES (?,?)"))
+
+			sql, _, err = sqlf.NewBuilder().Insert("accounts").Struct(account, sqlf.ExcludeColumns("id", "age")).ToSQL()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sql).To(Equal("INSERT INTO accounts (name) VALUES (?)"))
+
+			sql, _, err = sqlf.NewBuilder().Insert("accounts").Struct(account, sqlf.ReadOnlyColumns("age"), sqlf.PrimaryKeyColumns("name")).ToSQL()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sql).To(Equal("INSERT INTO accounts (id, name) VALUES (?,?)"))
+		})
+
+		It("should return the generated columns", func() {
+			sql, _, err := sqlf.NewBuilder().Dialect(sqlf.Postgres).Insert("accounts").Struct(&structAccount{Name: "john"}, sqlf.ReturningGenerated()).ToSQL()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sql).To(Equal("INSERT INTO accounts (name, age) VALUES ($1,$2) RETURNING id, created_at"))
+		})
+
+		It("should stop at the structs that embed their own type", func() {
+			sql, args, err := sqlf.NewBuilder().Insert("nodes").Struct(StructNode{ID: 1, StructNode: &StructNode{ID: 2}}).ToSQL()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sql).To(Equal("INSERT INTO nodes (id) VALUES (?)"))
+			Expect(args).To(Equal([]interface{}{int64(1)}))
+		})
+
+		It("should ignore the ambiguous embedded fields", func() {
+			contact := structContact{
+				Name:       "john",
+				structHome: structHome{Address: "home", Phone: "1"},
+				structWork: structWork{Address: "work", Phone: "2", Company: "acme"},
+			}
+			sql, args, err := sqlf.NewBuilder().Insert("contacts").Struct(contact).ToSQL()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sql).To(Equal("INSERT INTO contacts (name, phone, Phone) VALUES (?,?,?)"))
+			Expect(args).To(Equal([]interface{}{"john", "1", "acme"}))
+		})
+
+		It("should fail for invalid structs", func() {
+			_, _, err := sqlf.NewBuilder().Insert("accounts").Struct(1).ToSQL()
+			Expect(err).To(MatchError("sqlf: INSERT STRUCT: invalid struct: expected a struct or a pointer to a struct, got int"))
+			Expect(errors.Is(err, sqlf.ErrInvalidStruct)).To(BeTrue())
+
+			_, _, err = sqlf.NewBuilder().Insert("accounts").Structs([]int{1}).ToSQL()
+			Expect(errors.Is(err, sqlf.ErrInvalidStruct)).To(BeTrue())
+
+			_, _, err = sqlf.NewBuilder().Insert("accounts").Structs([]structAccount{}).ToSQL()
+			Expect(errors.Is(err, sqlf.ErrInvalidStruct)).To(BeTrue())
+
+			_, _, err = sqlf.NewBuilder().Insert("accounts").Struct(structAccount{}, sqlf.ReturningGenerated()).ToSQL()
+			Expect(errors.Is(err, sqlf.ErrInvalidStruct)).To(BeTrue(), "the struct cannot receive the generated columns")
+		})
+
+		It("should fail returning the generated columns for dialects without RETURNING", func() {
+			_, _, err := sqlf.NewBuilder().Dialect(sqlf.MySQL).Insert("accounts").Struct(&structAccount{Name: "john"}, sqlf.ReturningGenerated()).ToSQL()
+			var featureErr *sqlf.UnsupportedFeatureError
+			Expect(errors.As(err, &featureErr)).To(BeTrue())
+		})
+	})
+
+	Describe("Update", func() {
+		It("should set the columns of a struct", func() {
+			account := structAccount{ID: 1, Name: "john", Age: 30, CreatedAt: createdAt}
+			sql, args, err := sqlf.NewBuilder().Update("accounts").SetStruct(account).Where("id = ?", account.ID).ToSQL()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sql).To(Equal("UPDATE accounts SET name = ?, age = ? WHERE id = ?"))
+			Expect(args).To(Equal([]interface{}{"john", 30, int64(1)}))
+		})
+
+		It("should bind the []byte fields", func() {
+			credential := structCredential{ID: 1, Hash: []byte("'; DROP TABLE users; --")}
+			sql, args, err := sqlf.NewBuilder().Update("credentials").SetStruct(credential).Where("id = ?", credential.ID).ToSQL()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sql).To(Equal("UPDATE credentials SET hash = ?, avatar = ? WHERE id = ?"))
+			Expect(args).To(Equal([]interface{}{[]byte("'; DROP TABLE users; --"), []byte(nil), int64(1)}))
+		})
+
+		It("should append to the fields already set", func() {
+			sql, _, err := sqlf.NewBuilder().Update("accounts").Set("updated_at", createdAt).SetStruct(structAccount{Name: "john"}, sqlf.OmitEmpty()).ToSQL()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sql).To(Equal("UPDATE accounts SET updated_at = ?, name = ?"))
+		})
+
+		It("should return the generated columns", func() {
+			sql, _, err := sqlf.NewBuilder().Dialect(sqlf.Postgres).Update("accounts").SetStruct(&structAccount{Name: "john"}, sqlf.ReturningGenerated()).Where("id = ?", 1).ToSQL()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sql).To(Equal("UPDATE accounts SET name = $1, age = $2 WHERE id = $3 RETURNING id, created_at"))
+		})
+
+		It("should render a returning clause", func() {
+			sql, _, err := sqlf.NewBuilder().Update("accounts").Set("name", "john").Returning("id").ToSQL()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sql).To(Equal("UPDATE accounts SET name = ? RETURNING id"))
+		})
+
+		It("should fail for invalid structs", func() {
+			_, _, err := sqlf.NewBuilder().Update("accounts").SetStruct("john").ToSQL()
+			Expect(err).To(MatchError("sqlf: UPDATE STRUCT: invalid struct: expected a struct or a pointer to a struct, got string"))
+		})
+
+		It("should fail when no columns are picked", func() {
+			_, _, err := sqlf.NewBuilder().Update("accounts").SetStruct(structAccount{}, sqlf.OmitEmpty()).Where("id = ?", 1).ToSQL()
+			Expect(err).To(MatchError("sqlf: UPDATE STRUCT: invalid struct: no columns to update from sqlf_test.structAccount"))
+			Expect(errors.Is(err, sqlf.ErrInvalidStruct)).To(BeTrue())
+		})
+	})
+
+	Describe("Exec", func() {
+		var (
+			ctx context.Context
+			fd  *testingutils.FakeDriver
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			fd = &testingutils.FakeDriver{
+				Columns: []string{"id", "created_at"},
+				Rows:    [][]driver.Value{{int64(1), createdAt}, {int64(2), createdAt}},
+			}
+		})
+
+		It("should scan the generated columns back into the struct", func() {
+			db := fd.DB()
+			defer db.Close()
+
+			fd.Rows = fd.Rows[:1]
+			account := &structAccount{Name: "john"}
+			result, err := sqlf.NewBuilder().RunWith(db).Insert("accounts").Struct(account, sqlf.ReturningGenerated()).ExecContext(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(account.ID).To(Equal(int64(1)))
+			Expect(account.CreatedAt).To(Equal(createdAt))
+			Expect(result.RowsAffected()).To(Equal(int64(1)))
+			_, err = result.LastInsertId()
+			Expect(err).To(MatchError(sqlf.ErrNoLastInsertID))
+		})
+
+		It("should scan the generated columns back into the slice, in order", func() {
+			db := fd.DB()
+			defer db.Close()
+
+			accounts := []structAccount{{Name: "john"}, {Name: "jane"}}
+			_, err := sqlf.NewBuilder().RunWith(db).Insert("accounts").Structs(accounts, sqlf.ReturningGenerated()).ExecContext(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(accounts[0].ID).To(Equal(int64(1)))
+			Expect(accounts[1].ID).To(Equal(int64(2)))
+			Expect(fd.LastQuery().SQL).To(Equal("INSERT INTO accounts (name, age) VALUES (?,?), (?,?) RETURNING id, created_at"))
+		})
+
+		It("should fail when the rows returned are not one per struct", func() {
+			fd.Rows = fd.Rows[:1]
+			db := fd.DB()
+			defer db.Close()
+
+			accounts := []structAccount{{Name: "john"}, {Name: "jane"}}
+			_, err := sqlf.NewBuilder().RunWith(db).Insert("accounts").Structs(accounts, sqlf.ReturningGenerated()).ExecContext(ctx)
+			Expect(err).To(MatchError("sqlf: the rows returned do not match the structs: got 1 rows for 2 structs"))
+			Expect(errors.Is(err, sqlf.ErrReturningMismatch)).To(BeTrue())
+			Expect(accounts[0].ID).To(BeZero(), "the structs should be left untouched")
+
+			fd.Rows = nil
+			_, err = sqlf.NewBuilder().RunWith(db).Insert("accounts").Struct(&structAccount{Name: "john"}, sqlf.ReturningGenerated()).ExecContext(ctx)
+			Expect(errors.Is(err, sqlf.ErrReturningMismatch)).To(BeTrue())
+		})
+
+		It("should scan the generated columns of an update back into the struct", func() {
+			db := fd.DB()
+			defer db.Close()
+
+			account := &structAccount{Name: "john"}
+			_, err := sqlf.NewBuilder().RunWith(db).Update("accounts").SetStruct(account, sqlf.ReturningGenerated()).Where("id = ?", 1).ExecContext(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(account.ID).To(Equal(int64(1)))
+			Expect(account.CreatedAt).To(Equal(createdAt))
+		})
+
+		It("should execute without scanning when nothing is returned", func() {
+			fd.RowsAffected = 1
+			db := fd.DB()
+			defer db.Close()
+
+			result, err := sqlf.NewBuilder().RunWith(db).Insert("accounts").Struct(structAccount{Name: "john"}).ExecContext(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RowsAffected()).To(Equal(int64(1)))
+		})
+	})
+})
@@ -0,0 +1,67 @@
+package sqlf
+
+var (
+	sqlExists    = []byte("EXISTS ")
+	sqlNotExists = []byte("NOT EXISTS ")
+)
+
+// subquery renders a statement wrapped in brackets.
+type subquery struct {
+	statement FastSqlizer
+}
+
+// ToSQLFast generates the SQL and returns it, alongside its params.
+func (sub *subquery) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
+	sb.Write(sqlBracketOpen)
+	err := sub.statement.ToSQLFast(sb, args)
+	if err != nil {
+		return err
+	}
+	sb.Write(sqlBracketClose)
+	return nil
+}
+
+// Subquery wraps a statement in brackets so it can be used as an expression. The statement is rendered inline,
+// into the same writer (and placeholder format) of the outer statement.
+//
+// Predicates (`Eq`, `Lt`, `In`, etc) already wrap `Select`s automatically. So, `Eq("total", sel)` renders
+// `total = (SELECT ...)`.
+func Subquery(statement FastSqlizer) FastSqlizer {
+	return &subquery{
+		statement: statement,
+	}
+}
+
+// existsPredicate renders the `EXISTS` and `NOT EXISTS` predicates.
+type existsPredicate struct {
+	operator  []byte
+	statement FastSqlizer
+}
+
+// ToSQLFast generates the SQL and returns it, alongside its params.
+func (exists *existsPredicate) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
+	sb.Write(exists.operator)
+	sb.Write(sqlBracketOpen)
+	err := exists.statement.ToSQLFast(sb, args)
+	if err != nil {
+		return err
+	}
+	sb.Write(sqlBracketClose)
+	return nil
+}
+
+// Exists renders `EXISTS (<statement>)`.
+func Exists(statement FastSqlizer) FastSqlizer {
+	return &existsPredicate{
+		operator:  sqlExists,
+		statement: statement,
+	}
+}
+
+// NotExists renders `NOT EXISTS (<statement>)`.
+func NotExists(statement FastSqlizer) FastSqlizer {
+	return &existsPredicate{
+		operator:  sqlNotExists,
+		statement: statement,
+	}
+}
@@ -0,0 +1,84 @@
+package sqlf_test
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/jamillosantos/sqlf"
+	"github.com/jamillosantos/sqlf/testingutils"
+)
+
+var _ = Describe("Subqueries", func() {
+	It("should generate an EXISTS predicate", func() {
+		sql, args, err := new(sqlf.SelectStatement).
+			Placeholder(sqlf.DollarPlaceholder).
+			From("users", "u").
+			Where("u.account_id = ?", 7).
+			WhereCriteria(sqlf.Exists(
+				new(sqlf.SelectStatement).Select("1").From("permissions", "p").Where("p.user_id = u.id AND p.name = ?", "admin"),
+			)).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(Equal([]interface{}{7, "admin"}))
+		Expect(sql).To(Equal("SELECT * FROM users AS u WHERE u.account_id = $1 AND EXISTS (SELECT 1 FROM permissions AS p WHERE p.user_id = u.id AND p.name = $2)"))
+	})
+
+	It("should generate a NOT EXISTS predicate", func() {
+		sb, args := new(strings.Builder), make([]interface{}, 0)
+		err := sqlf.NotExists(new(sqlf.SelectStatement).Select("1").From("bans").Where("bans.user_id = users.id")).ToSQLFast(sb, &args)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(BeEmpty())
+		Expect(sb.String()).To(Equal("NOT EXISTS (SELECT 1 FROM bans WHERE bans.user_id = users.id)"))
+	})
+
+	It("should generate an IN with a subquery", func() {
+		sql, args, err := new(sqlf.SelectStatement).
+			Placeholder(sqlf.DollarPlaceholder).
+			From("users").
+			WhereCriteria(
+				sqlf.Eq("active", true),
+				sqlf.In("id", new(sqlf.SelectStatement).Select("user_id").From("admins").Where("level > ?", 2)),
+			).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(Equal([]interface{}{true, 2}))
+		Expect(sql).To(Equal("SELECT * FROM users WHERE active = $1 AND id IN (SELECT user_id FROM admins WHERE level > $2)"))
+	})
+
+	It("should generate a comparison against a scalar subquery", func() {
+		sql, args, err := new(sqlf.SelectStatement).
+			Placeholder(sqlf.DollarPlaceholder).
+			From("orders", "o").
+			WhereCriteria(
+				sqlf.Gt("o.total", new(sqlf.SelectStatement).Select("AVG(total)").From("orders").Where("account_id = ?", 7)),
+				sqlf.Eq("o.status", "paid"),
+			).
+			ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(Equal([]interface{}{7, "paid"}))
+		Expect(sql).To(Equal("SELECT * FROM orders AS o WHERE o.total > (SELECT AVG(total) FROM orders WHERE account_id = $1) AND o.status = $2"))
+	})
+
+	It("should generate a subquery as a select field", func() {
+		sb, args := new(strings.Builder), make([]interface{}, 0)
+		err := new(sqlf.SelectStatement).
+			Select("u.id", sqlf.Subquery(new(sqlf.SelectStatement).Select("COUNT(*)").From("orders").Where("orders.user_id = u.id"))).
+			From("users", "u").
+			ToSQLFast(sb, &args)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(BeEmpty())
+		Expect(sb.String()).To(Equal("SELECT u.id, (SELECT COUNT(*) FROM orders WHERE orders.user_id = u.id) FROM users AS u"))
+	})
+
+	It("should fail generating an errored subquery", func() {
+		sb, args := new(strings.Builder), make([]interface{}, 0)
+		err := sqlf.Exists(&testingutils.MockerSqlizer{
+			Err: errors.New("forced error"),
+		}).ToSQLFast(sb, &args)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("forced error"))
+	})
+})
@@ -0,0 +1,163 @@
+package testingutils
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+)
+
+// FakeQuery is a query received by the `FakeDriver`.
+type FakeQuery struct {
+	SQL  string
+	Args []interface{}
+}
+
+// FakeDriver is an in-process `database/sql/driver` implementation that records the queries it receives and
+// answers them with its `Columns` and `Rows`. Use `DB` to get a `*sql.DB` backed by it.
+type FakeDriver struct {
+	// Columns are the names of the columns returned by the queries.
+	Columns []string
+
+	// Rows are the rows returned by the queries.
+	Rows [][]driver.Value
+
+	// RowsAffected is returned by the `sql.Result` of the executions.
+	RowsAffected int64
+
+	// LastInsertID is returned by the `sql.Result` of the executions.
+	LastInsertID int64
+
+	// Err, when defined, is returned by every query and execution.
+	Err error
+
+	mu      sync.Mutex
+	queries []FakeQuery
+}
+
+// DB returns a `*sql.DB` that uses the driver.
+func (d *FakeDriver) DB() *sql.DB {
+	return sql.OpenDB(d)
+}
+
+// Queries returns the queries received by the driver, in order.
+func (d *FakeDriver) Queries() []FakeQuery {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]FakeQuery(nil), d.queries...)
+}
+
+// LastQuery returns the last query received by the driver.
+func (d *FakeDriver) LastQuery() FakeQuery {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.queries) == 0 {
+		return FakeQuery{}
+	}
+	return d.queries[len(d.queries)-1]
+}
+
+// Open implements `driver.Driver`.
+func (d *FakeDriver) Open(string) (driver.Conn, error) {
+	return &fakeConn{driver: d}, nil
+}
+
+// Connect implements `driver.Connector`.
+func (d *FakeDriver) Connect(context.Context) (driver.Conn, error) {
+	return &fakeConn{driver: d}, nil
+}
+
+// Driver implements `driver.Connector`.
+func (d *FakeDriver) Driver() driver.Driver {
+	return d
+}
+
+// record stores the query and returns the configured error.
+func (d *FakeDriver) record(query string, args []driver.NamedValue) error {
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	d.mu.Lock()
+	d.queries = append(d.queries, FakeQuery{SQL: query, Args: values})
+	d.mu.Unlock()
+	return d.Err
+}
+
+type fakeConn struct {
+	driver *FakeDriver
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("testingutils: the fake driver does not support prepared statements")
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return c, nil
+}
+
+func (c *fakeConn) Commit() error {
+	return nil
+}
+
+func (c *fakeConn) Rollback() error {
+	return nil
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	err := c.driver.record(query, args)
+	if err != nil {
+		return nil, err
+	}
+	return fakeResult{c.driver.LastInsertID, c.driver.RowsAffected}, nil
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	err := c.driver.record(query, args)
+	if err != nil {
+		return nil, err
+	}
+	return &fakeRows{columns: c.driver.Columns, rows: c.driver.Rows}, nil
+}
+
+type fakeResult struct {
+	lastInsertID int64
+	rowsAffected int64
+}
+
+func (r fakeResult) LastInsertId() (int64, error) {
+	return r.lastInsertID, nil
+}
+
+func (r fakeResult) RowsAffected() (int64, error) {
+	return r.rowsAffected, nil
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+	cursor  int
+}
+
+func (r *fakeRows) Columns() []string {
+	return r.columns
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.cursor >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.cursor])
+	r.cursor++
+	return nil
+}
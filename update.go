@@ -3,11 +3,34 @@ package sqlf
 // Update describes how a UPDATE will behave into the sqlf.
 type Update interface {
 	Sqlizer
+	InterpolatedSqlizer
+	Runner
 	FastSqlizer
 
 	// Placeholder defines the placeholder format that should be used for this update statement.
 	Placeholder(placeholder PlaceholderFormatFactory) Update
 
+	// QuoteIdentifiers enables quoting the table and alias of the update automatically, using `quoter`. The `quoter`
+	// is also used for the `Identifier`s and aliases (`As`) rendered as part of the update.
+	QuoteIdentifiers(quoter IdentifierQuoter) Update
+
+	// Dialect defines the database technology the update is rendered for. The dialect provides the placeholder
+	// format (when `Placeholder` is not defined), the identifier quoting and the syntax of the features that differ
+	// between databases. Using a feature the dialect does not support returns an `UnsupportedFeatureError`.
+	Dialect(dialect Dialect) Update
+
+	// Strict enables the strict mode for the update, and the statements nested into it. Check `Select.Strict`.
+	Strict() Update
+
+	// EmptySlices defines how the empty slice arguments of the conditions are rendered. Check `Select.EmptySlices`.
+	EmptySlices(style EmptySliceStyle) Update
+
+	// Bind defines the values of the named parameters of the conditions. Check `Select.Bind`.
+	Bind(values interface{}) Update
+
+	// RunWith defines the `Executor` used to run the update. Check `Select.RunWith`.
+	RunWith(db Executor) Update
+
 	// Table defines what table will be updated.
 	Table(tableName ...string) Update
 
@@ -19,6 +42,13 @@ type Update interface {
 	// and so on. Hence, the number of arguments passed should, always, be even.
 	Set(fieldAndValues ...interface{}) Update
 
+	// SetStruct appends the fields of the struct `value` (or a pointer to it), alongside their values, to the fields
+	// that will be updated. Primary keys are never updated. Check `StructOption` for how the columns are picked. Ex:
+	//
+	//	b.Update("users").SetStruct(&user, sqlf.OmitEmpty()).Where("id = ?", user.ID)
+	//
+	SetStruct(value interface{}, opts ...StructOption) Update
+
 	// Where appends a condition. If called multiples, the conditions will be appended.
 	//
 	// The conditions added will use the AND operator.
@@ -28,4 +58,7 @@ type Update interface {
 	//
 	// The conditions added will use the AND operator.
 	WhereClause(conditions ...FastSqlizer) Update
+
+	// Returning defines the RETURNING clause defined for Postgres (and SQLite).
+	Returning(fields ...interface{}) Update
 }
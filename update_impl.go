@@ -1,8 +1,11 @@
 package sqlf
 
 import (
+	"context"
+	"database/sql"
 	"errors"
-	"strings"
+	"fmt"
+	"reflect"
 )
 
 var (
@@ -17,10 +20,20 @@ var (
 
 type UpdateStatement struct {
 	placeholderFormat PlaceholderFormatFactory
+	quoter            IdentifierQuoter
+	dialect           Dialect
+	strict            bool
+	emptySlices       EmptySliceStyle
+	bindings          *bindings
+	registry          *Registry
+	executor          Executor
 	tableName         string
 	as                string
 	fields            []interface{}
 	where             []FastSqlizer
+	returning         []interface{}
+	structErr         error
+	returningTargets  []reflect.Value
 }
 
 // Placeholder defines the placeholder format that should be used for this delete statement.
@@ -29,6 +42,70 @@ func (update *UpdateStatement) Placeholder(placeholder PlaceholderFormatFactory)
 	return update
 }
 
+// QuoteIdentifiers enables quoting the table and alias of the update automatically, using `quoter`. The `quoter`
+// is also used for the `Identifier`s and aliases (`As`) rendered as part of the update.
+func (update *UpdateStatement) QuoteIdentifiers(quoter IdentifierQuoter) Update {
+	update.quoter = quoter
+	return update
+}
+
+// Dialect defines the database technology the update is rendered for. The dialect provides the placeholder
+// format (when `Placeholder` is not defined), the identifier quoting and the syntax of the features that differ
+// between databases. Using a feature the dialect does not support returns an `UnsupportedFeatureError`.
+func (update *UpdateStatement) Dialect(dialect Dialect) Update {
+	update.dialect = dialect
+	return update
+}
+
+// Strict enables the strict mode for the update, and the statements nested into it. Check `Select.Strict`.
+func (update *UpdateStatement) Strict() Update {
+	update.strict = true
+	return update
+}
+
+// EmptySlices defines how the empty slice arguments of the conditions are rendered. Check `Select.EmptySlices`.
+func (update *UpdateStatement) EmptySlices(style EmptySliceStyle) Update {
+	update.emptySlices = style
+	return update
+}
+
+// Bind defines the values of the named parameters of the conditions. Check `Select.Bind`.
+func (update *UpdateStatement) Bind(values interface{}) Update {
+	update.bindings = newBindings(values)
+	return update
+}
+
+// RunWith defines the `Executor` used to run the update. Check `Select.RunWith`.
+func (update *UpdateStatement) RunWith(db Executor) Update {
+	update.executor = db
+	return update
+}
+
+// ExecContext executes the update with its `Executor`, without returning any rows.
+//
+// When `SetStruct` was used with `ReturningGenerated`, the generated columns of the first row are scanned back into
+// the struct.
+func (update *UpdateStatement) ExecContext(ctx context.Context) (sql.Result, error) {
+	if len(update.returningTargets) > 0 {
+		rows, err := queryContext(ctx, update.executor, update)
+		if err != nil {
+			return nil, err
+		}
+		return scanReturning(rows, update.returningTargets, false)
+	}
+	return execContext(ctx, update.executor, update)
+}
+
+// QueryContext executes the update with its `Executor`, returning its rows.
+func (update *UpdateStatement) QueryContext(ctx context.Context) (*sql.Rows, error) {
+	return queryContext(ctx, update.executor, update)
+}
+
+// QueryRowContext executes the update with its `Executor`, returning at most one row. Check `Row`.
+func (update *UpdateStatement) QueryRowContext(ctx context.Context) *Row {
+	return queryRowContext(ctx, update.executor, update)
+}
+
 // Table defines what table will be deleted.
 func (update *UpdateStatement) Table(tableName ...string) Update {
 	if len(tableName) > 0 {
@@ -55,6 +132,31 @@ func (update *UpdateStatement) Set(fieldAndValues ...interface{}) Update {
 	return update
 }
 
+// SetStruct appends the fields of the struct `value` (or a pointer to it), alongside their values, to the fields
+// that will be updated. Primary keys are never updated. Check `StructOption` for how the columns are picked. Ex:
+//
+//	b.Update("users").SetStruct(&user, sqlf.OmitEmpty()).Where("id = ?", user.ID)
+//
+// Errors, including a struct without any column to update, are returned when the update is rendered.
+func (update *UpdateStatement) SetStruct(value interface{}, opts ...StructOption) Update {
+	columns, err := pickStructColumns(value, false, false, opts)
+	if err == nil && len(columns.columns) == 0 {
+		err = fmt.Errorf("%w: no columns to update from %T", ErrInvalidStruct, value)
+	}
+	if err != nil {
+		update.structErr = err
+		return update
+	}
+	for i, column := range columns.columns {
+		update.fields = append(update.fields, column, boundArg{value: columns.values[i]})
+	}
+	update.returningTargets = columns.targets
+	if len(columns.returning) > 0 {
+		update.returning = columns.returning
+	}
+	return update
+}
+
 // Where appends a condition. If called multiples, the conditions will be appended.
 //
 // The conditions added will use the AND operator.
@@ -81,9 +183,15 @@ func (update *UpdateStatement) WhereClause(conditions ...FastSqlizer) Update {
 	return update
 }
 
+// Returning defines the RETURNING clause defined for Postgres (and SQLite).
+func (update *UpdateStatement) Returning(fields ...interface{}) Update {
+	update.returning = fields
+	return update
+}
+
 // ToSQL generates the SQL and returns it, alongside its params.
 func (update *UpdateStatement) ToSQL() (string, []interface{}, error) {
-	sb := new(strings.Builder)
+	sb := new(renderBuilder)
 	args := make([]interface{}, 0)
 	err := update.ToSQLFast(sb, &args)
 	if err != nil {
@@ -92,25 +200,59 @@ func (update *UpdateStatement) ToSQL() (string, []interface{}, error) {
 	return sb.String(), args, nil
 }
 
+// ToInterpolatedSQL generates the SQL with its params inlined as literals, for logging and debugging. Check
+// `InterpolatedSQL`.
+func (update *UpdateStatement) ToInterpolatedSQL() (InterpolatedSQL, error) {
+	return ToInterpolatedSQL(update)
+}
+
+// statementDialect returns the dialect of the update, used to write the literals of `ToInterpolatedSQL`.
+func (update *UpdateStatement) statementDialect() Dialect {
+	return update.dialect
+}
+
 // ToSQLFast generates the SQL and returns it, alongside its params.
 func (update *UpdateStatement) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
-	if update.placeholderFormat != nil {
-		sb = update.placeholderFormat.Wrap(sb)
-	}
+	session := beginRender(sb, args, update.placeholderFormat, renderContext{
+		dialect:     update.dialect,
+		quoter:      update.quoter,
+		quoteAll:    update.quoter != nil,
+		strict:      update.strict,
+		emptySlices: update.emptySlices,
+		bindings:    update.bindings,
+		registry:    update.registry,
+	})
+	return session.end(update.render(session.sb, args))
+}
 
+// render writes the update into the already prepared `sb`.
+func (update *UpdateStatement) render(sb SQLWriter, args *[]interface{}) error {
+	err := update.bindings.bindError()
+	if err != nil {
+		return renderError("UPDATE", "BIND", nil, err)
+	}
+	if update.structErr != nil {
+		return renderError("UPDATE", "STRUCT", nil, update.structErr)
+	}
 	// Writing >> UPDATE <TABLE> SET <<
 	sb.Write(sqlUpdateStatement)
-	sb.WriteString(update.tableName)
+	err = writeTable(sb, update.tableName)
+	if err != nil {
+		return renderError("UPDATE", "TABLE", update.tableName, err)
+	}
 	if update.as != "" {
 		sb.Write(sqlSelectAsClause)
-		sb.WriteString(update.as)
+		err := writeAlias(sb, update.as)
+		if err != nil {
+			return renderError("UPDATE", "AS", update.as, err)
+		}
 	}
 	sb.Write(sqlUpdateSetClause)
 
 	// Enforce the key-pair for the set clause.
 	lenFields := len(update.fields)
 	if lenFields%2 != 0 {
-		return ErrUpdateInvalidFieldValuePairCount
+		return renderError("UPDATE", "SET", update.fields[lenFields-1], ErrUpdateInvalidFieldValuePairCount)
 	}
 
 	// Writing update <table> set >> field = value <<
@@ -120,12 +262,12 @@ func (update *UpdateStatement) ToSQLFast(sb SQLWriter, args *[]interface{}) erro
 		}
 		err := RenderInterfaceAsSQL(sb, args, update.fields[i])
 		if err != nil {
-			return err
+			return renderError("UPDATE", clauseAt("SET", i/2), update.fields[i], err)
 		}
 		sb.Write(sqlUpdateAssignOperation)
 		err = RenderInterfaceAsArg(sb, args, update.fields[i+1])
 		if err != nil {
-			return err
+			return renderError("UPDATE", clauseAt("SET", i/2), update.fields[i], err)
 		}
 	}
 
@@ -134,13 +276,26 @@ func (update *UpdateStatement) ToSQLFast(sb SQLWriter, args *[]interface{}) erro
 		sb.Write(sqlWhereClause)
 
 		// Writing update <table> set field = value where >> <CONDITIONS> <<
-		for idx, condition := range update.where {
+		err := writeConditions(sb, args, "UPDATE", "WHERE", update.where)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(update.returning) > 0 {
+		// Writing update <table> set field = value where <conditions> >> RETURNING <fields> <<
+		err := checkFeature(sb, FeatureReturning)
+		if err != nil {
+			return renderError("UPDATE", "RETURNING", nil, err)
+		}
+		sb.Write(sqlInsertReturningClause)
+		for idx, field := range update.returning {
 			if idx > 0 {
-				sb.Write(sqlConditionAnd)
+				sb.Write(sqlComma)
 			}
-			err := RenderInterfaceAsSQL(sb, args, condition)
+			err := RenderInterfaceAsSQL(sb, args, field)
 			if err != nil {
-				return err
+				return renderError("UPDATE", clauseAt("RETURNING", idx), field, err)
 			}
 		}
 	}
@@ -57,7 +57,7 @@ var _ = Describe("Update", func() {
 		Expect(err).To(HaveOccurred())
 		Expect(args).To(BeNil())
 		Expect(sql).To(BeEmpty())
-		Expect(err.Error()).To(Equal("forced error"))
+		Expect(err).To(MatchError("sqlf: UPDATE SET #1: forced error"))
 	})
 
 	It("should generate a UPDATE with errored value", func() {
@@ -71,7 +71,7 @@ var _ = Describe("Update", func() {
 		Expect(err).To(HaveOccurred())
 		Expect(args).To(BeNil())
 		Expect(sql).To(BeEmpty())
-		Expect(err.Error()).To(Equal("forced error"))
+		Expect(err).To(MatchError(`sqlf: UPDATE SET #1 "name": forced error`))
 	})
 
 	It("should fail generating an UPDATE with wrong field and values count", func() {
@@ -86,7 +86,8 @@ var _ = Describe("Update", func() {
 		Expect(err).To(HaveOccurred())
 		Expect(args).To(BeNil())
 		Expect(sql).To(BeEmpty())
-		Expect(err).To(Equal(sqlf.ErrUpdateInvalidFieldValuePairCount))
+		Expect(err).To(MatchError(sqlf.ErrUpdateInvalidFieldValuePairCount))
+		Expect(err).To(MatchError(`sqlf: UPDATE SET "email": invalid field and value pair count`))
 	})
 
 	It("should generate a UPDATE with the WHERE clause", func() {
@@ -115,7 +116,7 @@ var _ = Describe("Update", func() {
 		Expect(err).To(HaveOccurred())
 		Expect(args).To(BeNil())
 		Expect(sql).To(BeEmpty())
-		Expect(err.Error()).To(Equal("forced error"))
+		Expect(err).To(MatchError("sqlf: UPDATE WHERE #1: forced error"))
 	})
 
 	It("should generate a UPDATE with the multiple WHERE conditions", func() {
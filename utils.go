@@ -1,6 +1,7 @@
 package sqlf
 
 import (
+	"database/sql/driver"
 	"fmt"
 )
 
@@ -9,7 +10,18 @@ import (
 //
 // Sqlizer types are welcome and, if args are present they will be appended to the
 // given `args` pointer.
+//
+// The types registered in the `Registry` of the statement are rendered by their `TypeRenderer.SQL`.
+//
+// In strict mode (check `Select.Strict`), only strings, `[]byte`s and `FastSqlizer`s are accepted, and raw SQL
+// cannot contain statement terminators or comments.
 func RenderInterfaceAsSQL(sb SQLWriter, args *[]interface{}, element interface{}) error {
+	if ok, err := renderRegisteredSQL(sb, args, element); ok {
+		return err
+	}
+	if isStrict(sb) {
+		return renderStrictSQL(sb, args, element)
+	}
 	switch p := element.(type) {
 	case string:
 		sb.WriteString(p)
@@ -30,24 +42,68 @@ func RenderInterfaceAsSQL(sb SQLWriter, args *[]interface{}, element interface{}
 
 // RenderInterfaceAsArg renders the input element into the `SQLWriter`
 // according with its type considering the input as an argument.
+//
+// `FastSqlizer`s are rendered in place (use `RawSQL` to write raw SQL). Any other value is bound, unchanged, to a
+// `?` placeholder. So, `driver.Valuer`s and the native driver types (including `time.Time` and `[]byte`) reach the
+// driver as they are. A `fmt.Stringer` is not bound as its `String()`, unless it is wrapped by `AsString`.
+//
+// The types registered in the `Registry` of the statement are rendered by their `TypeRenderer.Arg`.
 func RenderInterfaceAsArg(sb SQLWriter, args *[]interface{}, element interface{}) error {
-	switch p := element.(type) {
-	case []byte:
-		sb.Write(p)
-	case FastSqlizer:
-		err := p.ToSQLFast(sb, args)
-		if err != nil {
-			return err
-		}
-	case fmt.Stringer:
-		sb.WriteString("?")
-		*args = append(*args, p.String())
-	case string:
-		sb.WriteString("?")
-		*args = append(*args, p)
-	default:
-		sb.WriteString("?")
-		*args = append(*args, element)
+	if ok, err := renderRegisteredArg(sb, args, element); ok {
+		return err
+	}
+	if p, ok := element.(FastSqlizer); ok {
+		return p.ToSQLFast(sb, args)
 	}
+	sb.Write(sqlPredicatePlaceholder)
+	*args = append(*args, element)
 	return nil
 }
+
+// RawSQL is written as it is where a value would be bound (check `RenderInterfaceAsArg`). In strict mode (check
+// `Select.Strict`), it cannot contain statement terminators or comments. Ex: `Set("seen_at", RawSQL("NOW()"))`.
+type RawSQL string
+
+// ToSQLFast writes the raw SQL.
+func (raw RawSQL) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
+	err := checkRawSQL(sb, string(raw))
+	if err != nil {
+		return err
+	}
+	sb.WriteString(string(raw))
+	return nil
+}
+
+// boundArg always binds its value, even the `FastSqlizer`s (as `RawSQL`) that `RenderInterfaceAsArg` renders in
+// place. It is used for the values that come from data, as the fields of a struct (check `Update.SetStruct`).
+type boundArg struct {
+	value interface{}
+}
+
+// ToSQLFast renders the registered types by their `TypeRenderer.Arg` and binds the other values unchanged.
+func (arg boundArg) ToSQLFast(sb SQLWriter, args *[]interface{}) error {
+	if ok, err := renderRegisteredArg(sb, args, arg.value); ok {
+		return err
+	}
+	sb.Write(sqlPredicatePlaceholder)
+	*args = append(*args, arg.value)
+	return nil
+}
+
+// stringValuer binds a `fmt.Stringer` as its `String()`.
+type stringValuer struct {
+	value fmt.Stringer
+}
+
+// Value implements the `driver.Valuer` interface.
+func (s stringValuer) Value() (driver.Value, error) {
+	return s.value.String(), nil
+}
+
+// AsString binds `value` as its `String()`. Args are bound unchanged, so it is the explicit way to store a type as
+// text. Ex: `Eq("status", AsString(StatusActive))` binds "active" instead of the enum value.
+func AsString(value fmt.Stringer) driver.Valuer {
+	return stringValuer{
+		value: value,
+	}
+}
@@ -0,0 +1,100 @@
+package sqlf_test
+
+import (
+	"database/sql/driver"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/jamillosantos/sqlf"
+	"github.com/jamillosantos/sqlf/testingutils"
+)
+
+// status is an enum that implements `fmt.Stringer`.
+type status int
+
+func (s status) String() string {
+	if s == 1 {
+		return "active"
+	}
+	return "inactive"
+}
+
+// money is a `driver.Valuer` that also implements `fmt.Stringer`.
+type money int64
+
+func (m money) Value() (driver.Value, error) {
+	return int64(m), nil
+}
+
+func (m money) String() string {
+	return "$" + strconv.FormatInt(int64(m), 10)
+}
+
+var _ = Describe("RenderInterfaceAsArg", func() {
+	createdAt := time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC)
+
+	DescribeTable("should bind the values unchanged",
+		func(value interface{}) {
+			sb, args := new(strings.Builder), make([]interface{}, 0)
+			Expect(sqlf.RenderInterfaceAsArg(sb, &args, value)).To(Succeed())
+			Expect(sb.String()).To(Equal("?"))
+			Expect(args).To(Equal([]interface{}{value}))
+		},
+		Entry("time.Time", createdAt),
+		Entry("net.IP", net.ParseIP("10.0.0.1")),
+		Entry("fmt.Stringer", status(1)),
+		Entry("driver.Valuer", money(10)),
+		Entry("[]byte", []byte("abc")),
+		Entry("string", "name"),
+		Entry("nil", nil),
+	)
+
+	It("should write RawSQL as it is", func() {
+		sb, args := new(strings.Builder), make([]interface{}, 0)
+		Expect(sqlf.RenderInterfaceAsArg(sb, &args, sqlf.RawSQL("NOW()"))).To(Succeed())
+		Expect(sb.String()).To(Equal("NOW()"))
+		Expect(args).To(BeEmpty())
+
+		sql, args, err := new(sqlf.UpdateStatement).Table("users").Set("seen_at", sqlf.RawSQL("NOW()"), "name", []byte("'x'; DROP TABLE users")).ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sql).To(Equal("UPDATE users SET seen_at = NOW(), name = ?"))
+		Expect(args).To(Equal([]interface{}{[]byte("'x'; DROP TABLE users")}))
+
+		_, _, err = new(sqlf.UpdateStatement).Strict().Table("users").Set("name", sqlf.RawSQL("'x'; DROP TABLE users")).ToSQL()
+		Expect(errors.Is(err, sqlf.ErrUnsafeSQL)).To(BeTrue())
+	})
+
+	It("should render a FastSqlizer in place", func() {
+		sb, args := new(strings.Builder), make([]interface{}, 0)
+		Expect(sqlf.RenderInterfaceAsArg(sb, &args, &testingutils.MockerSqlizer{SQL: "NOW() - ?", Args: []interface{}{1}})).To(Succeed())
+		Expect(sb.String()).To(Equal("NOW() - ?"))
+		Expect(args).To(Equal([]interface{}{1}))
+	})
+
+	It("should bind timestamps of updates as time.Time", func() {
+		_, args, err := new(sqlf.UpdateStatement).Table("users").Set("updated_at", createdAt).ToSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(Equal([]interface{}{createdAt}))
+	})
+})
+
+var _ = Describe("AsString", func() {
+	It("should bind the String() of the value", func() {
+		value, err := sqlf.AsString(status(1)).Value()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal("active"))
+	})
+
+	It("should be inlined as a string by the interpolation", func() {
+		sql, err := new(sqlf.UpdateStatement).Table("users").Set("status", sqlf.AsString(status(1))).ToInterpolatedSQL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(sql)).To(HaveSuffix("UPDATE users SET status = 'active'"))
+	})
+})